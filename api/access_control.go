@@ -0,0 +1,246 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+const (
+	accessControlPath = "/sony/accessControl"
+
+	// authCookieName is the name of the cookie returned by the TV after pairing
+	authCookieName = "auth"
+)
+
+// AccessControlService handles requests related to registering this client with the TV
+type AccessControlService service
+
+// Registration identifies this client to the TV when pairing
+type Registration struct {
+	ClientID string `json:"clientid"` // Unique ID of the client, e.g. "bravia:6b7a..."
+	Nickname string `json:"nickname"` // Name shown in the TV's list of registered devices
+	Level    string `json:"level"`    // Access level, defaults to "private"
+}
+
+// registrationFunction is a function the client asks access to when registering
+type registrationFunction struct {
+	Value    string `json:"value"`
+	Function string `json:"function"`
+}
+
+// ActRegisterResult is the response from the actRegister method
+type ActRegisterResult = Result[[0]struct{}]
+
+// ActRegister registers the client with the TV. When the client isn't registered yet, the TV
+// shows a PIN on screen and the call fails with ErrUnauthorized; calling it again through a
+// client configured with WithPIN completes the registration. The auth cookie is set on the response.
+func (s *AccessControlService) ActRegister(ctx context.Context, registration Registration) (*ActRegisterResult, *http.Response, error) {
+	if registration.Level == "" {
+		registration.Level = "private"
+	}
+
+	params := [2]interface{}{registration, []registrationFunction{{Value: "yes", Function: "WOL"}}}
+	return Call[[0]struct{}](ctx, s.client, accessControlPath, "actRegister", "1.0", params)
+}
+
+// Register registers the client with the TV and returns the auth cookie it sets
+func (s *AccessControlService) Register(ctx context.Context, registration Registration) (*http.Cookie, error) {
+	_, resp, err := s.ActRegister(ctx, registration)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == authCookieName {
+			return cookie, nil
+		}
+	}
+	return nil, errors.New("bravia: TV did not return an auth cookie")
+}
+
+// Pair registers the client with the TV using PIN pairing and returns the auth cookie.
+// If the TV asks for a PIN, it shows one on screen and pin is called to obtain it from the user.
+func Pair(ctx context.Context, c *Client, registration Registration, pin func(ctx context.Context) (string, error)) (*http.Cookie, error) {
+	cookie, err := c.AccessControl.Register(ctx, registration)
+	if err == nil {
+		// Already registered, the TV renewed the cookie without asking for a PIN
+		return cookie, nil
+	}
+	if !errors.Is(err, ErrUnauthorized) {
+		return nil, err
+	}
+
+	code, err := pin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.WithPIN(code).AccessControl.Register(ctx, registration)
+}
+
+// basicAuthTransport is an http.RoundTripper that sends the pairing PIN as basic auth password
+type basicAuthTransport struct {
+	// transport is the RoundTripper to use for the request
+	transport http.RoundTripper
+	// PIN is the PIN shown on the TV
+	PIN string
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *basicAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.SetBasicAuth("", t.PIN)
+	return t.transport.RoundTrip(req)
+}
+
+// WithPIN returns a new client that sends the PIN shown on the TV, to complete pairing
+func (c *Client) WithPIN(pin string) *Client {
+	clone := c.copy()
+	defer clone.initialize()
+
+	clone.client.Transport = &basicAuthTransport{
+		transport: c.transport(),
+		PIN:       pin,
+	}
+
+	return clone
+}
+
+// authCookieTransport is an http.RoundTripper that adds the auth cookie to requests,
+// optionally renewing it when the TV rejects it
+type authCookieTransport struct {
+	// transport is the RoundTripper to use for the request
+	transport http.RoundTripper
+	// renew registers the client again and returns the new cookie, if set
+	renew func(ctx context.Context) (string, error)
+
+	mu       sync.Mutex
+	cookie   string
+	renewing chan struct{} // Closed when the renewal in progress ends, nil when there is none
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *authCookieTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	cookie := t.cookie
+	t.mu.Unlock()
+
+	resp, err := t.transport.RoundTrip(t.withCookie(req, cookie))
+	if err != nil || t.renew == nil || req.GetBody == nil {
+		return resp, err
+	}
+	if resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden {
+		return resp, nil
+	}
+
+	// The cookie expired, register again and retry once with the new one
+	renewed, err := t.renewCookie(req.Context(), cookie)
+	if err != nil {
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	retry := t.withCookie(req, renewed)
+	retry.Body = body
+
+	return t.transport.RoundTrip(retry)
+}
+
+// renewCookie renews the cookie, unless another request already did since stale was sent.
+// Requests finding a renewal in progress wait for it rather than registering again.
+func (t *authCookieTransport) renewCookie(ctx context.Context, stale string) (string, error) {
+	t.mu.Lock()
+	if t.cookie != stale {
+		cookie := t.cookie
+		t.mu.Unlock()
+		return cookie, nil
+	}
+	if done := t.renewing; done != nil {
+		t.mu.Unlock()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if t.cookie == stale {
+			return "", errors.New("bravia: failed to renew the auth cookie")
+		}
+		return t.cookie, nil
+	}
+	done := make(chan struct{})
+	t.renewing = done
+	t.mu.Unlock()
+
+	// Register without holding the lock, so requests with a valid cookie aren't held up
+	cookie, err := t.renew(ctx)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err == nil {
+		t.cookie = cookie
+	}
+	t.renewing = nil
+	close(done)
+
+	return cookie, err
+}
+
+// withCookie returns a copy of req carrying the auth cookie
+func (t *authCookieTransport) withCookie(req *http.Request, cookie string) *http.Request {
+	req = req.Clone(req.Context())
+	req.Header.Del("Cookie")
+	req.AddCookie(&http.Cookie{Name: authCookieName, Value: cookie})
+	return req
+}
+
+// WithAuthCookie returns a new client configured to use the auth cookie obtained by pairing
+func (c *Client) WithAuthCookie(cookie string) *Client {
+	clone := c.copy()
+	defer clone.initialize()
+
+	clone.authCookie = cookie
+	clone.client.Transport = &authCookieTransport{
+		transport: c.transport(),
+		cookie:    cookie,
+	}
+
+	return clone
+}
+
+// WithPairing returns a new client configured to use the auth cookie obtained by pairing, which
+// registers again transparently when the cookie expires. onRenew, if set, is called with the new
+// cookie so it can be persisted.
+func (c *Client) WithPairing(registration Registration, cookie string, onRenew func(cookie string)) *Client {
+	clone := c.copy()
+	defer clone.initialize()
+
+	// Renew through the original client, which doesn't send the stale cookie
+	renew := func(ctx context.Context) (string, error) {
+		renewed, err := c.AccessControl.Register(ctx, registration)
+		if err != nil {
+			return "", err
+		}
+		if onRenew != nil {
+			onRenew(renewed.Value)
+		}
+		return renewed.Value, nil
+	}
+
+	clone.authCookie = cookie
+	clone.client.Transport = &authCookieTransport{
+		transport: c.transport(),
+		renew:     renew,
+		cookie:    cookie,
+	}
+
+	return clone
+}
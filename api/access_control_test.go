@@ -0,0 +1,57 @@
+package api_test
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/trugamr/bravia/api"
+	"github.com/trugamr/bravia/api/bravatest"
+)
+
+func TestPairingRenewsCookie(t *testing.T) {
+	srv := bravatest.NewServer(bravatest.WithPSK("secret"))
+	defer srv.Close()
+
+	baseURL, _ := url.Parse(srv.URL)
+	client := api.NewClient(baseURL)
+	ctx := context.Background()
+
+	registration := api.Registration{ClientID: "bravia:test", Nickname: "Test"}
+	cookie, err := api.Pair(ctx, client, registration, func(context.Context) (string, error) {
+		return "0000", nil
+	})
+	if err != nil {
+		t.Fatalf("Pair() error = %v", err)
+	}
+
+	var renewals atomic.Int32
+	var renewed atomic.Value
+	paired := client.WithPairing(registration, cookie.Value, func(cookie string) {
+		renewals.Add(1)
+		renewed.Store(cookie)
+	})
+
+	// Requests rejected at the same time register again only once
+	srv.TV.ExpireCookies()
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := paired.System.GetPowerStatus(ctx); err != nil {
+				t.Errorf("GetPowerStatus() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := renewals.Load(); got != 1 {
+		t.Errorf("cookie renewed %d times, want 1", got)
+	}
+	if got, _ := renewed.Load().(string); got == "" || got == cookie.Value {
+		t.Errorf("renewed cookie = %q, want a new cookie", got)
+	}
+}
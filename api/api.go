@@ -2,13 +2,17 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"slices"
+	"sync/atomic"
+	"time"
 )
 
-// TODO: Create common response struct with error and result as they all have the same format
-// TODO: Handle errors returned from the API
+//go:generate go run ./internal/apigen -output methods_gen.go
 
 const (
 	// headerAuthPSK is the header used for the pre-shared key authentication
@@ -25,20 +29,65 @@ type Client struct {
 
 	BaseURL *url.URL // The base URL for the API
 
+	timeout       time.Duration  // The time limit of each request set by WithTimeout, if any
+	userAgent     string         // The User-Agent header sent with requests, if set
+	logger        *slog.Logger   // The logger requests are logged to, if set
+	requestHooks  []RequestHook  // Called with each request before it is sent
+	responseHooks []ResponseHook // Called with the outcome of each request
+
+	lastID atomic.Int64 // The ID of the last JSON-RPC request sent
+
+	negotiate    bool             // Whether to negotiate method versions with the TV
+	capabilities *capabilityCache // Capabilities of the TV, shared between copies of the client
+	irccCodes    *irccCodeCache   // IRCC codes accepted by the TV, shared between copies of the client
+
+	retry            *RetryPolicy    // How failed requests are retried, if at all
+	concurrencyLimit int             // Maximum number of requests in flight to each host, 0 for no limit
+	breaker          *CircuitBreaker // When requests fail fast while the TV is unreachable, if at all
+
+	psk        string // The pre-shared key set by WithAuthPSK, if any
+	authCookie string // The auth cookie set by WithAuthCookie, if any
+
 	// Services used for interacting with different parts of the API
-	System     *SystemService
-	Audio      *AudioService
-	AppControl *AppControlService
-	AVContent  *AVContentService
-	IRCC       *IRCCService
+	System        *SystemService
+	Audio         *AudioService
+	AppControl    *AppControlService
+	AVContent     *AVContentService
+	Video         *VideoService
+	AccessControl *AccessControlService
+	IRCC          *IRCCService
 }
 
-func NewClient(baseURL *url.URL) *Client {
+// NewClient returns a new client for the TV at baseURL, configured with the given options
+func NewClient(baseURL *url.URL, opts ...Option) *Client {
 	client := &http.Client{}
 	c := &Client{
-		client:  client,
-		BaseURL: baseURL,
+		client:       client,
+		BaseURL:      baseURL,
+		capabilities: &capabilityCache{},
+		irccCodes:    &irccCodeCache{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	// Build the client from the options, whatever order they were given in
+	if c.timeout > 0 {
+		c.client.Timeout = c.timeout
+	}
+	if c.logger != nil {
+		c.client.Transport = &traceTransport{transport: c.transport(), logger: c.logger}
+	}
+	if c.retry != nil || c.concurrencyLimit > 0 || c.breaker != nil {
+		c.client.Transport = c.middleware(c.transport())
+	}
+	if c.psk != "" {
+		c.client.Transport = &authPSKTransport{transport: c.transport(), PSK: c.psk}
 	}
+	if c.authCookie != "" {
+		c.client.Transport = &authCookieTransport{transport: c.transport(), cookie: c.authCookie}
+	}
+
 	c.initialize()
 	return c
 }
@@ -49,6 +98,8 @@ func (c *Client) initialize() {
 	c.Audio = &AudioService{client: c}
 	c.AppControl = &AppControlService{client: c}
 	c.AVContent = &AVContentService{client: c}
+	c.Video = &VideoService{client: c}
+	c.AccessControl = &AccessControlService{client: c}
 	c.IRCC = &IRCCService{client: c}
 }
 
@@ -75,12 +126,17 @@ func (c *Client) NewRequest(method, path string, body interface{}) (*http.Reques
 		req.Header.Set("Content-Type", "application/json")
 	}
 	req.Header.Set("Accept", "application/json")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
 
 	return req, nil
 }
 
-func (c *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
-	resp, err := c.client.Do(req)
+// Do sends the request using the given context and decodes the JSON response into v.
+// If the context is canceled or times out, the context's error is returned.
+func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*http.Response, error) {
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return resp, err
 	}
@@ -89,6 +145,10 @@ func (c *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
 	if v != nil {
 		err := json.NewDecoder(resp.Body).Decode(v)
 		if err != nil {
+			// Some errors come without a JSON-RPC body, report the HTTP status instead
+			if resp.StatusCode >= http.StatusBadRequest {
+				return resp, &Error{Code: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+			}
 			return resp, err
 		}
 	}
@@ -96,18 +156,63 @@ func (c *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
 	return resp, nil
 }
 
-// copy returns a copy of the client
+// do sends the request bound to ctx, preferring the context's error over the transport error
+func (c *Client) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	for _, hook := range c.requestHooks {
+		hook(req)
+	}
+
+	resp, err := c.client.Do(req)
+	// If the context was canceled or timed out, that is the more useful error
+	if ctxErr := ctx.Err(); err != nil && ctxErr != nil {
+		err = ctxErr
+	}
+
+	for _, hook := range c.responseHooks {
+		hook(req, resp, err)
+	}
+
+	return resp, err
+}
+
+// copy returns a copy of the client, preserving all its settings
 func (c *Client) copy() *Client {
+	client := *c.client
 	clone := Client{
-		client: &http.Client{
-			Transport: c.client.Transport,
-		},
+		client:  &client,
 		BaseURL: c.BaseURL,
+
+		timeout:       c.timeout,
+		userAgent:     c.userAgent,
+		logger:        c.logger,
+		requestHooks:  slices.Clone(c.requestHooks),
+		responseHooks: slices.Clone(c.responseHooks),
+
+		negotiate:    c.negotiate,
+		capabilities: c.capabilities,
+		irccCodes:    c.irccCodes,
+
+		retry:            c.retry,
+		concurrencyLimit: c.concurrencyLimit,
+		breaker:          c.breaker,
+
+		psk:        c.psk,
+		authCookie: c.authCookie,
 	}
 
 	return &clone
 }
 
+// transport returns the transport of the client, to be wrapped by a new one
+func (c *Client) transport() http.RoundTripper {
+	// Preserve the transport if it exists
+	if c.client.Transport != nil {
+		return c.client.Transport
+	}
+	return http.DefaultTransport
+}
+
 // authPSKTransport is an http.RoundTripper that adds the pre-shared key header to requests
 type authPSKTransport struct {
 	// transport is the RoundTripper to use for the request
@@ -127,20 +232,62 @@ func (c *Client) WithAuthPSK(psk string) *Client {
 	clone := c.copy()
 	defer clone.initialize()
 
-	// Preserve the transport if it exists
-	nextTransport := c.client.Transport
-	if nextTransport == nil {
-		nextTransport = http.DefaultTransport
-	}
-
+	clone.psk = psk
 	clone.client.Transport = &authPSKTransport{
-		transport: nextTransport,
+		transport: c.transport(),
 		PSK:       psk,
 	}
 
 	return clone
 }
 
+// Call invokes a JSON-RPC method of the service at path and decodes its result into a Result[R].
+// The generated service methods are built on top of it, and it can be used directly to call
+// methods this package doesn't wrap yet, for example:
+//
+//	result, _, err := api.Call[[1]string](ctx, client, "/sony/system", "getCurrentTime", "1.0", [0]struct{}{})
+func Call[R, P any](ctx context.Context, c *Client, path, method, version string, params P) (*Result[R], *http.Response, error) {
+	body := Payload[P]{
+		Method:  method,
+		ID:      c.nextID(),
+		Params:  params,
+		Version: version,
+	}
+
+	req, err := c.NewRequest(http.MethodPost, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Reading state is safe to retry
+	if isIdempotentMethod(method) {
+		ctx = withIdempotent(ctx)
+	}
+
+	start := time.Now()
+	result := new(Result[R])
+	resp, err := c.Do(ctx, req, result)
+	if err != nil {
+		result = nil
+	} else if result.HasError() {
+		err = result.Err()
+	}
+
+	c.logCall(ctx, "bravia call", start, resp, err,
+		slog.String("path", path),
+		slog.String("method", method),
+		slog.String("version", version),
+		slog.Int("id", body.ID),
+	)
+
+	return result, resp, err
+}
+
+// nextID returns the ID to use for the next JSON-RPC request
+func (c *Client) nextID() int {
+	return int(c.lastID.Add(1))
+}
+
 // Result is a generic response struct that conforms to the JSON response format
 type Result[T interface{}] struct {
 	Error  *[2]interface{} `json:"error,omitempty"`
@@ -156,11 +303,19 @@ func (r *Result[T]) HasError() bool {
 // ErrorMessage returns the error message if the result has an error
 func (r *Result[T]) ErrorMessage() string {
 	if r.HasError() {
-		return r.Error[1].(string)
+		return newError(*r.Error).Message
 	}
 	return ""
 }
 
+// Err returns the error as an *Error if the result has an error, otherwise nil
+func (r *Result[T]) Err() error {
+	if r.HasError() {
+		return newError(*r.Error)
+	}
+	return nil
+}
+
 // Payload is a generic payload struct that conforms to the JSON request format
 type Payload[T interface{}] struct {
 	Method  string `json:"method"`
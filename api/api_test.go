@@ -0,0 +1,155 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/trugamr/bravia/api"
+	"github.com/trugamr/bravia/api/bravatest"
+)
+
+func TestPSK(t *testing.T) {
+	srv := bravatest.NewServer(bravatest.WithPSK("secret"))
+	defer srv.Close()
+
+	baseURL, _ := url.Parse(srv.URL)
+
+	tests := []struct {
+		name    string
+		client  *api.Client
+		wantErr error
+	}{
+		{"valid", api.NewClient(baseURL, api.WithAuthPSK("secret")), nil},
+		{"wrong", api.NewClient(baseURL, api.WithAuthPSK("wrong")), api.ErrForbidden},
+		{"missing", api.NewClient(baseURL), api.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := tt.client.System.GetPowerStatus(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetPowerStatus() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFault(t *testing.T) {
+	srv := bravatest.NewServer(bravatest.WithPSK("secret"))
+	defer srv.Close()
+
+	client := srv.APIClient()
+	ctx := context.Background()
+
+	// A fault applying to a single call
+	srv.TV.SetFault("getPowerStatus", bravatest.Fault{Err: api.ErrIllegalState, Times: 1})
+	if _, _, err := client.System.GetPowerStatus(ctx); !errors.Is(err, api.ErrIllegalState) {
+		t.Errorf("GetPowerStatus() error = %v, want %v", err, api.ErrIllegalState)
+	}
+	if _, _, err := client.System.GetPowerStatus(ctx); err != nil {
+		t.Errorf("GetPowerStatus() after the fault error = %v", err)
+	}
+
+	// A bare HTTP status is reported as an *api.Error with the status as code
+	srv.TV.SetFault("getPowerStatus", bravatest.Fault{Status: http.StatusServiceUnavailable})
+	_, _, err := client.System.GetPowerStatus(ctx)
+	var apiErr *api.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusServiceUnavailable {
+		t.Errorf("GetPowerStatus() error = %v, want code %d", err, http.StatusServiceUnavailable)
+	}
+	srv.TV.ClearFault("getPowerStatus")
+
+	// A delayed response is abandoned once the context is done
+	srv.TV.SetFault("getPowerStatus", bravatest.Fault{Delay: time.Second})
+	timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, _, err := client.System.GetPowerStatus(timeout); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetPowerStatus() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestDisplayOffInStandby(t *testing.T) {
+	srv := bravatest.NewServer(bravatest.WithPSK("secret"), bravatest.WithPower(false))
+	defer srv.Close()
+
+	client := srv.APIClient()
+	ctx := context.Background()
+
+	if _, _, err := client.Audio.GetVolumeInformation(ctx); !errors.Is(err, api.ErrDisplayOff) {
+		t.Errorf("GetVolumeInformation() error = %v, want %v", err, api.ErrDisplayOff)
+	}
+
+	// The power status is still answered in standby
+	result, _, err := client.System.GetPowerStatus(ctx)
+	if err != nil {
+		t.Fatalf("GetPowerStatus() error = %v", err)
+	}
+	if got := (*result.Result)[0].Status; got != "standby" {
+		t.Errorf("GetPowerStatus() = %q, want standby", got)
+	}
+
+	if _, _, err := client.System.SetPowerStatus(ctx, true); err != nil {
+		t.Fatalf("SetPowerStatus() error = %v", err)
+	}
+	if _, _, err := client.Audio.GetVolumeInformation(ctx); err != nil {
+		t.Errorf("GetVolumeInformation() after power on error = %v", err)
+	}
+}
+
+func TestSendIRCCCommand(t *testing.T) {
+	srv := bravatest.NewServer(bravatest.WithPSK("secret"))
+	defer srv.Close()
+
+	client := srv.APIClient()
+	ctx := context.Background()
+
+	if _, err := client.IRCC.SendIRCCCommand(ctx, string(api.IRCCHome)); err != nil {
+		t.Fatalf("SendIRCCCommand() error = %v", err)
+	}
+	if got := srv.TV.SentCodes(); !slices.Equal(got, []string{string(api.IRCCHome)}) {
+		t.Errorf("SentCodes() = %v, want %v", got, []string{string(api.IRCCHome)})
+	}
+
+	// Power keys change the power state
+	if _, err := client.IRCC.SendIRCCCommand(ctx, string(api.IRCCPowerOff)); err != nil {
+		t.Fatalf("SendIRCCCommand() error = %v", err)
+	}
+	if srv.TV.Power() {
+		t.Error("Power() = true after the power off key")
+	}
+}
+
+func TestSendIRCCCommandFault(t *testing.T) {
+	srv := bravatest.NewServer(bravatest.WithPSK("secret"))
+	defer srv.Close()
+
+	client := srv.APIClient()
+	ctx := context.Background()
+
+	// Unknown codes are answered with a SOAP fault
+	_, err := client.IRCC.SendIRCCCommand(ctx, "AAAAAQAAAAEAAAD/Aw==")
+	var irccErr *api.IRCCError
+	if !errors.As(err, &irccErr) || irccErr.Code != 800 {
+		t.Errorf("SendIRCCCommand() error = %v, want IRCC error 800", err)
+	}
+
+	// Errors without a SOAP fault are reported with their HTTP status
+	srv.TV.SetFault("X_SendIRCC", bravatest.Fault{Status: http.StatusServiceUnavailable, Times: 1})
+	_, err = client.IRCC.SendIRCCCommand(ctx, string(api.IRCCHome))
+	var apiErr *api.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusServiceUnavailable {
+		t.Errorf("SendIRCCCommand() error = %v, want code %d", err, http.StatusServiceUnavailable)
+	}
+	if got := srv.TV.SentCodes(); len(got) != 0 {
+		t.Errorf("SentCodes() = %v, want none", got)
+	}
+
+	if _, err := client.IRCC.SendIRCCCommand(ctx, string(api.IRCCHome)); err != nil {
+		t.Errorf("SendIRCCCommand() after the fault error = %v", err)
+	}
+}
@@ -1,8 +1,11 @@
 package api
 
 import (
+	"context"
 	"errors"
-	"net/http"
+	"fmt"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -12,121 +15,205 @@ const (
 // AudioService handles requests related to audio, such as setting the volume and muting
 type AudioService service
 
-type SetAudioVolumeResult = Result[[1]int]
+// VolumeInfo represents volume information for a target
+type VolumeInfo struct {
+	Target    string `json:"target"`
+	Volume    int    `json:"volume"`
+	Mute      bool   `json:"mute"`
+	MaxVolume int    `json:"maxVolume"`
+	MinVolume int    `json:"minVolume"`
+}
 
-type setAudioVolumeParams [1]struct {
-	// Volume specifies the volume level to set.
-	// The following formats are accepted:
-	//   "N"   - Sets the volume to level N, where N is a numeric string (e.g., "25").
-	//   "+N"  - Increases the volume by N, where N is a numeric string (e.g., "+14").
-	//   "-N"  - Decreases the volume by N, where N is a numeric string (e.g., "-10").
-	Volume string `json:"volume"`
+// ClampVolume returns the level to set the volume of info to for level, which is in the format
+// accepted by SetAudioVolume: "N" for an absolute level, "+N" or "-N" for a relative change.
+// The result is clamped between the minimum and maximum volume of info.
+func ClampVolume(level string, info VolumeInfo) (int, error) {
+	n, relative, err := parseVolumeLevel(level)
+	if err != nil {
+		return 0, err
+	}
 
-	Target string `json:"target"`
+	if relative {
+		n += info.Volume
+	}
+	return min(max(n, info.MinVolume), info.MaxVolume), nil
 }
 
-type setAudioVolumePayload Payload[setAudioVolumeParams]
-
-// SetAudioVolume sets the volume of the TV
-func (s *AudioService) SetAudioVolume(volume, target string) (*SetAudioVolumeResult, *http.Response, error) {
-	body := setAudioVolumePayload{
-		Method:  "setAudioVolume",
-		ID:      1,
-		Params:  setAudioVolumeParams{{Volume: volume, Target: target}},
-		Version: "1.0",
+// parseVolumeLevel parses a level in the format accepted by SetAudioVolume, reporting whether
+// it's a relative change
+func parseVolumeLevel(level string) (int, bool, error) {
+	n, err := strconv.Atoi(level)
+	if err != nil || level == "" {
+		return 0, false, fmt.Errorf("bravia: invalid volume level %q, expected N, +N or -N: %w", level, ErrIllegalArgument)
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, audioPath, body)
+	// Relative changes are signed, absolute levels are not
+	return n, strings.HasPrefix(level, "+") || strings.HasPrefix(level, "-"), nil
+}
+
+// SetVolume sets the volume of target, or of every target if it is empty, to level in the format
+// accepted by ClampVolume. Relative changes are sent to the TV as they are, so they add up when
+// made at the same time, and the TV keeps them between the minimum and maximum volume itself.
+// Absolute levels are validated and clamped between the minimum and maximum volume of each
+// target before calling the TV.
+func (s *AudioService) SetVolume(ctx context.Context, target, level string) error {
+	_, relative, err := parseVolumeLevel(level)
 	if err != nil {
-		return nil, nil, err
+		return err
+	}
+	if relative {
+		_, _, err := s.SetAudioVolume(ctx, level, target, nil)
+		return err
 	}
 
-	result := new(SetAudioVolumeResult)
-	resp, err := s.client.Do(req, result)
+	result, _, err := s.GetVolumeInformation(ctx)
 	if err != nil {
-		return nil, resp, err
+		return err
+	}
+	if result.Result == nil || len(*result.Result) == 0 {
+		return errors.New("bravia: getVolumeInformation returned no result")
 	}
 
-	if result.HasError() {
-		return result, resp, errors.New(result.ErrorMessage())
+	var volumes []VolumeInfo
+	for _, info := range (*result.Result)[0] {
+		if target == "" || info.Target == target {
+			volumes = append(volumes, info)
+		}
+	}
+	if len(volumes) == 0 {
+		return fmt.Errorf("bravia: volume target %q is not supported by this TV: %w", target, ErrIllegalArgument)
 	}
 
-	return result, resp, nil
+	for _, info := range volumes {
+		volume, err := ClampVolume(level, info)
+		if err != nil {
+			return err
+		}
+		if _, _, err := s.SetAudioVolume(ctx, strconv.Itoa(volume), info.Target, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-// VolumeInfo represents volume information for a target
-type VolumeInfo struct {
-	Target    string `json:"target"`
-	Volume    int    `json:"volume"`
-	Mute      bool   `json:"mute"`
-	MaxVolume int    `json:"maxVolume"`
-	MinVolume int    `json:"minVolume"`
+// Sound setting targets
+const (
+	SoundOutputTerminal = "outputTerminal" // Where the sound is played, see the OutputTerminal values
+)
+
+// Output terminal values, not every model supports all of them
+const (
+	OutputTerminalSpeaker     = "speaker"      // TV speakers
+	OutputTerminalSpeakerHDMI = "speaker_hdmi" // TV speakers and HDMI ARC at the same time
+	OutputTerminalHDMI        = "hdmi"         // HDMI ARC, such as a soundbar
+	OutputTerminalAudioSystem = "audioSystem"  // Audio system connected with HDMI ARC or optical
+)
+
+// Speaker setting targets, not every model supports all of them
+const (
+	SpeakerTVPosition     = "tvPosition"     // See the TVPosition values
+	SpeakerSubwooferLevel = "subwooferLevel" // Level of the wireless subwoofer
+	SpeakerSoundField     = "soundField"     // Sound field, such as "cinema" or "music"
+)
+
+// TV position values, the TV tunes its speakers for where it stands
+const (
+	TVPositionTableTop  = "tableTop"
+	TVPositionWallMount = "wallMount"
+)
+
+// AudioSetting represents a sound or speaker setting, its value and the values it accepts
+type AudioSetting struct {
+	Target       string           `json:"target"`
+	CurrentValue string           `json:"currentValue"`
+	Title        string           `json:"title,omitempty"`
+	Candidate    []AudioCandidate `json:"candidate,omitempty"`
 }
 
-// GetVolumeInformationResult is the response from the getVolumeInformation method
-type GetVolumeInformationResult = Result[[1][]VolumeInfo]
+// AudioCandidate represents an accepted value of a sound or speaker setting,
+// either a named value or a numeric range
+type AudioCandidate struct {
+	Candidate
+	Title       string `json:"title,omitempty"` // Title shown by the TV, if any
+	IsAvailable bool   `json:"isAvailable"`     // Whether the value can be selected in the current state
+}
 
-type getVolumeInformationParams [0]struct{}
-type getVolumeInformationPayload Payload[getVolumeInformationParams]
+// AudioSettingValue represents a value to set a sound or speaker setting to
+type AudioSettingValue struct {
+	Target string `json:"target"`
+	Value  string `json:"value"`
+}
 
-// GetVolumeInformation returns volume information for all targets
-func (s *AudioService) GetVolumeInformation() (*GetVolumeInformationResult, *http.Response, error) {
-	body := getVolumeInformationPayload{
-		Method:  "getVolumeInformation",
-		ID:      1,
-		Params:  getVolumeInformationParams{},
-		Version: "1.0",
+// Validate returns value as accepted by the setting, matching named values regardless of case.
+// Values that exist but can't be selected right now, such as an audio system that isn't
+// connected, are reported as ErrIllegalState.
+func (s AudioSetting) Validate(value string) (string, error) {
+	candidates := make([]Candidate, len(s.Candidate))
+	for i, candidate := range s.Candidate {
+		candidates[i] = candidate.Candidate
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, audioPath, body)
+	i, matched, err := matchCandidate(s.Target+" value", value, candidates)
 	if err != nil {
-		return nil, nil, err
+		return "", err
 	}
+	if i >= 0 && !s.Candidate[i].IsAvailable {
+		return "", fmt.Errorf("bravia: %s %s is not available right now: %w", s.Target, matched, ErrIllegalState)
+	}
+	return matched, nil
+}
 
-	result := new(GetVolumeInformationResult)
-	resp, err := s.client.Do(req, result)
+// SetSound validates value against the candidates the TV returns for the sound setting target
+// and sets it, returning the value as accepted by the TV
+func (s *AudioService) SetSound(ctx context.Context, target, value string) (string, error) {
+	result, _, err := s.GetSoundSettings(ctx, target)
 	if err != nil {
-		return nil, resp, err
+		return "", err
 	}
-
-	if result.HasError() {
-		return result, resp, errors.New(result.ErrorMessage())
+	if result.Result == nil || len(*result.Result) == 0 {
+		return "", errors.New("bravia: getSoundSettings returned no result")
 	}
 
-	return result, resp, nil
-}
-
-// SetAudioMuteResult is the response from the setAudioMute method
-type SetAudioMuteResult = Result[[1]bool]
-
-type setAudioMuteParams [1]struct {
-	Status bool `json:"status"`
-}
-type setAudioMutePayload Payload[setAudioMuteParams]
+	value, err = validateAudioSetting("sound", (*result.Result)[0], target, value)
+	if err != nil {
+		return "", err
+	}
 
-// SetAudioMute sets the audio mute status
-func (s *AudioService) SetAudioMute(status bool) (*SetAudioMuteResult, *http.Response, error) {
-	body := setAudioMutePayload{
-		Method:  "setAudioMute",
-		ID:      1,
-		Params:  setAudioMuteParams{{Status: status}},
-		Version: "1.0",
+	if _, _, err := s.SetSoundSettings(ctx, []AudioSettingValue{{Target: target, Value: value}}); err != nil {
+		return "", err
 	}
+	return value, nil
+}
 
-	req, err := s.client.NewRequest(http.MethodPost, audioPath, body)
+// SetSpeaker validates value against the candidates the TV returns for the speaker setting target
+// and sets it, returning the value as accepted by the TV
+func (s *AudioService) SetSpeaker(ctx context.Context, target, value string) (string, error) {
+	result, _, err := s.GetSpeakerSettings(ctx, target)
 	if err != nil {
-		return nil, nil, err
+		return "", err
+	}
+	if result.Result == nil || len(*result.Result) == 0 {
+		return "", errors.New("bravia: getSpeakerSettings returned no result")
 	}
 
-	result := new(SetAudioMuteResult)
-	resp, err := s.client.Do(req, result)
+	value, err = validateAudioSetting("speaker", (*result.Result)[0], target, value)
 	if err != nil {
-		return nil, resp, err
+		return "", err
 	}
 
-	if result.HasError() {
-		return result, resp, errors.New(result.ErrorMessage())
+	if _, _, err := s.SetSpeakerSettings(ctx, []AudioSettingValue{{Target: target, Value: value}}); err != nil {
+		return "", err
 	}
+	return value, nil
+}
 
-	return result, resp, nil
+// validateAudioSetting finds target in settings and validates value against its candidates
+func validateAudioSetting(kind string, settings []AudioSetting, target, value string) (string, error) {
+	for _, setting := range settings {
+		if setting.Target == target {
+			return setting.Validate(value)
+		}
+	}
+	return "", fmt.Errorf("bravia: %s setting %q is not supported by this TV: %w", kind, target, ErrIllegalArgument)
 }
@@ -0,0 +1,56 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/trugamr/bravia/api"
+	"github.com/trugamr/bravia/api/bravatest"
+)
+
+func TestSetVolume(t *testing.T) {
+	srv := bravatest.NewServer(bravatest.WithPSK("secret"))
+	defer srv.Close()
+
+	client := srv.APIClient()
+	ctx := context.Background()
+
+	speakerVolume := func() int {
+		t.Helper()
+		info, ok := srv.TV.Volume("speaker")
+		if !ok {
+			t.Fatal("Volume(speaker) not found")
+		}
+		return info.Volume
+	}
+
+	// Absolute levels are clamped before calling the TV
+	if err := client.Audio.SetVolume(ctx, "speaker", "150"); err != nil {
+		t.Fatalf("SetVolume(150) error = %v", err)
+	}
+	if got := speakerVolume(); got != 100 {
+		t.Errorf("volume = %d, want the maximum of 100", got)
+	}
+
+	// Relative changes are sent as they are, without reading the volume first
+	srv.TV.SetFault("getVolumeInformation", bravatest.Fault{Err: api.ErrIllegalState})
+	for _, level := range []string{"-10", "-10", "+5"} {
+		if err := client.Audio.SetVolume(ctx, "speaker", level); err != nil {
+			t.Fatalf("SetVolume(%s) error = %v", level, err)
+		}
+	}
+	if got := speakerVolume(); got != 85 {
+		t.Errorf("volume = %d, want 85", got)
+	}
+	srv.TV.ClearFault("getVolumeInformation")
+
+	for _, level := range []string{"", "loud", "+", "1.5"} {
+		if err := client.Audio.SetVolume(ctx, "speaker", level); !errors.Is(err, api.ErrIllegalArgument) {
+			t.Errorf("SetVolume(%q) error = %v, want %v", level, err, api.ErrIllegalArgument)
+		}
+	}
+	if err := client.Audio.SetVolume(ctx, "subwoofer", "10"); !errors.Is(err, api.ErrIllegalArgument) {
+		t.Errorf("SetVolume(subwoofer) error = %v, want %v", err, api.ErrIllegalArgument)
+	}
+}
@@ -1,14 +1,21 @@
 package api
 
 import (
+	"context"
 	"errors"
-	"net/http"
+	"fmt"
+	"iter"
+	"strings"
 )
 
 const (
 	avContentPath = "/sony/avContent"
 )
 
+// contentPageSize is the most content items the TV returns per getContentList call
+const contentPageSize = 50
+
+// ExternalInputStatus represents the status of an external input
 type ExternalInputStatus struct {
 	URI    string `json:"uri"`
 	Title  string `json:"title"`
@@ -20,190 +27,21 @@ type ExternalInputStatus struct {
 // AVContentService handles requests related to AV content, such as setting inputs and playing content
 type AVContentService service
 
-// GetCurrentExternalInputsStatusResult is the response from the getCurrentExternalInputsStatus method
-type GetCurrentExternalInputsStatusResult = Result[[1][]ExternalInputStatus]
-
-type getCurrentExternalInputsStatusParams [0]struct{}
-type getCurrentExternalInputsStatusPayload Payload[getCurrentExternalInputsStatusParams]
-
-func (s *AVContentService) GetCurrentExternalInputsStatus() (*GetCurrentExternalInputsStatusResult, *http.Response, error) {
-	body := getCurrentExternalInputsStatusPayload{
-		Method:  "getCurrentExternalInputsStatus",
-		ID:      1,
-		Params:  getCurrentExternalInputsStatusParams{},
-		Version: "1.0",
-	}
-
-	req, err := s.client.NewRequest(http.MethodPost, avContentPath, body)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	result := new(GetCurrentExternalInputsStatusResult)
-	resp, err := s.client.Do(req, result)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	if result.HasError() {
-		return result, resp, errors.New(result.ErrorMessage())
-	}
-
-	return result, resp, nil
-}
-
-type SetPlayContentResult = Result[[0]struct{}]
-
-type setPlayContentParams [1]struct {
-	URI string `json:"uri"`
-}
-type setPlayContentPayload Payload[setPlayContentParams]
-
-func (s *AVContentService) SetPlayContent(uri string) (*SetPlayContentResult, *http.Response, error) {
-	body := setPlayContentPayload{
-		Method:  "setPlayContent",
-		ID:      1,
-		Params:  setPlayContentParams{{URI: uri}},
-		Version: "1.0",
-	}
-
-	req, err := s.client.NewRequest(http.MethodPost, avContentPath, body)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	result := new(SetPlayContentResult)
-	resp, err := s.client.Do(req, result)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	if result.HasError() {
-		return result, resp, errors.New(result.ErrorMessage())
-	}
-
-	return result, resp, nil
-}
-
 // Scheme represents a content scheme
 type Scheme struct {
 	Scheme string `json:"scheme"`
 }
 
-// GetSchemeListResult is the response from the getSchemeList method
-type GetSchemeListResult = Result[[1][]Scheme]
-
-type getSchemeListParams [0]struct{}
-type getSchemeListPayload Payload[getSchemeListParams]
-
-// GetSchemeList returns the list of available content schemes
-func (s *AVContentService) GetSchemeList() (*GetSchemeListResult, *http.Response, error) {
-	body := getSchemeListPayload{
-		Method:  "getSchemeList",
-		ID:      1,
-		Params:  getSchemeListParams{},
-		Version: "1.0",
-	}
-
-	req, err := s.client.NewRequest(http.MethodPost, avContentPath, body)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	result := new(GetSchemeListResult)
-	resp, err := s.client.Do(req, result)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	if result.HasError() {
-		return result, resp, errors.New(result.ErrorMessage())
-	}
-
-	return result, resp, nil
-}
-
 // Source represents a content source
 type Source struct {
 	Source string `json:"source"`
 }
 
-// GetSourceListResult is the response from the getSourceList method
-type GetSourceListResult = Result[[1][]Source]
-
-type getSourceListParams [1]struct {
-	Scheme string `json:"scheme"`
-}
-type getSourceListPayload Payload[getSourceListParams]
-
-// GetSourceList returns the list of sources for a given scheme
-func (s *AVContentService) GetSourceList(scheme string) (*GetSourceListResult, *http.Response, error) {
-	body := getSourceListPayload{
-		Method:  "getSourceList",
-		ID:      1,
-		Params:  getSourceListParams{{Scheme: scheme}},
-		Version: "1.0",
-	}
-
-	req, err := s.client.NewRequest(http.MethodPost, avContentPath, body)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	result := new(GetSourceListResult)
-	resp, err := s.client.Do(req, result)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	if result.HasError() {
-		return result, resp, errors.New(result.ErrorMessage())
-	}
-
-	return result, resp, nil
-}
-
 // ContentCount represents content count information
 type ContentCount struct {
 	Count int `json:"count"`
 }
 
-// GetContentCountResult is the response from the getContentCount method
-type GetContentCountResult = Result[[1]ContentCount]
-
-type getContentCountParams [1]struct {
-	Source string  `json:"source"`
-	Type   *string `json:"type,omitempty"`
-}
-type getContentCountPayload Payload[getContentCountParams]
-
-// GetContentCount returns the count of content items for a given source
-func (s *AVContentService) GetContentCount(source string, contentType *string) (*GetContentCountResult, *http.Response, error) {
-	body := getContentCountPayload{
-		Method:  "getContentCount",
-		ID:      1,
-		Params:  getContentCountParams{{Source: source, Type: contentType}},
-		Version: "1.0",
-	}
-
-	req, err := s.client.NewRequest(http.MethodPost, avContentPath, body)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	result := new(GetContentCountResult)
-	resp, err := s.client.Do(req, result)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	if result.HasError() {
-		return result, resp, errors.New(result.ErrorMessage())
-	}
-
-	return result, resp, nil
-}
-
 // ContentItem represents a content item
 type ContentItem struct {
 	URI   string `json:"uri"`
@@ -224,40 +62,85 @@ type ContentItem struct {
 	IsAlreadyPlayed  *bool   `json:"isAlreadyPlayed,omitempty"`
 }
 
-// GetContentListResult is the response from the getContentList method
-type GetContentListResult = Result[[1][]ContentItem]
+// PlayingContentInfo represents the content being played, such as an external input or a channel
+type PlayingContentInfo struct {
+	URI             string `json:"uri"`
+	Source          string `json:"source"`                    // Source of the content, e.g. "extInput:hdmi" or "tv:dvbt"
+	Title           string `json:"title"`                     // Title of the input or channel
+	DispNum         string `json:"dispNum,omitempty"`         // Channel number as shown by the TV
+	OriginalDispNum string `json:"originalDispNum,omitempty"` // Channel number given by the broadcaster
+	TripletStr      string `json:"tripletStr,omitempty"`      // DVB triplet of the channel
+	ProgramTitle    string `json:"programTitle,omitempty"`    // Title of the program being broadcast
+	StartDateTime   string `json:"startDateTime,omitempty"`   // Start time of the program
+	DurationSec     int    `json:"durationSec,omitempty"`     // Duration of the program in seconds
+	MediaType       string `json:"mediaType,omitempty"`       // Media type, e.g. "tv" or "radio"
+	PlaySpeed       string `json:"playSpeed,omitempty"`
+}
 
-type getContentListParams [1]struct {
-	Source string  `json:"source"`
-	StIdx  *int    `json:"stIdx,omitempty"`
-	Cnt    *int    `json:"cnt,omitempty"`
-	Type   *string `json:"type,omitempty"`
+// IsInput reports whether the content is an external input rather than a channel
+func (p PlayingContentInfo) IsInput() bool {
+	return strings.HasPrefix(p.URI, "extInput:")
 }
-type getContentListPayload Payload[getContentListParams]
 
-// GetContentList returns a list of content items for a given source
-func (s *AVContentService) GetContentList(source string, startIndex, count *int, contentType *string) (*GetContentListResult, *http.Response, error) {
-	body := getContentListPayload{
-		Method:  "getContentList",
-		ID:      1,
-		Params:  getContentListParams{{Source: source, StIdx: startIndex, Cnt: count, Type: contentType}},
-		Version: "1.0",
+// NowPlaying returns the input or channel being played. It returns nil without an error when
+// there is none, because an app is in the foreground or the display is off, which the TV
+// reports as an "illegal state" or "display off" error.
+func (s *AVContentService) NowPlaying(ctx context.Context) (*PlayingContentInfo, error) {
+	result, _, err := s.GetPlayingContentInfo(ctx)
+	if errors.Is(err, ErrIllegalState) || errors.Is(err, ErrDisplayOff) {
+		return nil, nil
 	}
-
-	req, err := s.client.NewRequest(http.MethodPost, avContentPath, body)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-
-	result := new(GetContentListResult)
-	resp, err := s.client.Do(req, result)
-	if err != nil {
-		return nil, resp, err
+	if result.Result == nil || len(*result.Result) == 0 {
+		return nil, errors.New("bravia: getPlayingContentInfo returned no result")
 	}
 
-	if result.HasError() {
-		return result, resp, errors.New(result.ErrorMessage())
-	}
+	info := (*result.Result)[0]
+	return &info, nil
+}
 
-	return result, resp, nil
+// ContentItems returns an iterator over all content items of a source, such as "tv:dvbt" or
+// "extInput:hdmi", fetching them from the TV a page at a time as the loop asks for more.
+// The items are counted first, as some TVs reject a start index past the last item.
+// An error ends the iteration, after being yielded with a zero ContentItem.
+func (s *AVContentService) ContentItems(ctx context.Context, source string) iter.Seq2[ContentItem, error] {
+	return func(yield func(ContentItem, error) bool) {
+		counted, _, err := s.GetContentCount(ctx, source, nil)
+		if err != nil {
+			yield(ContentItem{}, err)
+			return
+		}
+		if counted.Result == nil || len(*counted.Result) == 0 {
+			yield(ContentItem{}, fmt.Errorf("bravia: getContentCount of %s returned no result", source))
+			return
+		}
+		total := (*counted.Result)[0].Count
+
+		for start := 0; start < total; {
+			count := min(contentPageSize, total-start)
+			result, _, err := s.GetContentList(ctx, source, &start, &count, nil)
+			if err != nil {
+				yield(ContentItem{}, err)
+				return
+			}
+			if result.Result == nil || len(*result.Result) == 0 {
+				yield(ContentItem{}, fmt.Errorf("bravia: getContentList of %s returned no result", source))
+				return
+			}
+
+			// Some TVs return fewer items than asked for, and the list may shrink while paging
+			items := (*result.Result)[0]
+			if len(items) == 0 {
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			start += len(items)
+		}
+	}
 }
@@ -0,0 +1,85 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/trugamr/bravia/api"
+	"github.com/trugamr/bravia/api/bravatest"
+)
+
+// numberedChannels returns n dvbt channels numbered from 1
+func numberedChannels(n int) []api.ContentItem {
+	channels := make([]api.ContentItem, n)
+	for i := range channels {
+		dispNum := fmt.Sprintf("%03d", i+1)
+		channels[i] = api.ContentItem{
+			URI:     "tv:dvbt?trip=1.1." + dispNum,
+			Title:   "Channel " + dispNum,
+			Index:   i,
+			DispNum: &dispNum,
+		}
+	}
+	return channels
+}
+
+func TestContentItems(t *testing.T) {
+	// Page sized lists end without asking past the last item, which the fake TV rejects
+	for _, n := range []int{0, 7, 100, 123} {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			srv := bravatest.NewServer(bravatest.WithPSK("secret"), bravatest.WithChannels(numberedChannels(n)...))
+			defer srv.Close()
+
+			var got int
+			for item, err := range srv.APIClient().AVContent.ContentItems(context.Background(), "tv:dvbt") {
+				if err != nil {
+					t.Fatalf("ContentItems() error = %v", err)
+				}
+				if item.Index != got {
+					t.Errorf("item %d has index %d", got, item.Index)
+				}
+				got++
+			}
+			if got != n {
+				t.Errorf("ContentItems() returned %d items, want %d", got, n)
+			}
+		})
+	}
+}
+
+func TestContentItemsMissingResult(t *testing.T) {
+	// The TV counts the items, then answers the page without a result
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		w.Header().Set("Content-Type", "application/json")
+		if req.Method == "getContentCount" {
+			w.Write([]byte(`{"result": [{"count": 3}], "id": 1}`))
+			return
+		}
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer srv.Close()
+
+	baseURL, _ := url.Parse(srv.URL)
+	client := api.NewClient(baseURL)
+
+	var errs int
+	for _, err := range client.AVContent.ContentItems(context.Background(), "tv:dvbt") {
+		if err == nil {
+			t.Fatal("ContentItems() returned an item from a page without a result")
+		}
+		errs++
+	}
+	if errs != 1 {
+		t.Errorf("ContentItems() yielded %d errors, want 1", errs)
+	}
+}
@@ -0,0 +1,59 @@
+package bravatest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+)
+
+// serveAccessControl handles actRegister, which pairs a client using the PIN of the TV.
+// A registered client can register again without a PIN to get a new cookie.
+func (tv *TV) serveAccessControl(w http.ResponseWriter, r *http.Request, req request) {
+	if req.Method != "actRegister" {
+		writeJSON(w, http.StatusOK, errorResponse(req.ID, errNoSuchMethod))
+		return
+	}
+
+	var params [2]json.RawMessage
+	var registration struct {
+		ClientID string `json:"clientid"`
+	}
+	if err := json.Unmarshal(req.Params, &params); err != nil || json.Unmarshal(params[0], &registration) != nil || registration.ClientID == "" {
+		writeJSON(w, http.StatusOK, errorResponse(req.ID, errIllegalArgument))
+		return
+	}
+
+	tv.mu.Lock()
+	defer tv.mu.Unlock()
+
+	_, registered := tv.clients[registration.ClientID]
+	_, pin, _ := r.BasicAuth()
+	if !registered && pin != tv.pin {
+		writeJSON(w, http.StatusUnauthorized, errorResponse(req.ID, errUnauthorized))
+		return
+	}
+
+	cookie := newCookie()
+	tv.clients[registration.ClientID] = cookie
+
+	http.SetCookie(w, &http.Cookie{Name: "auth", Value: cookie, Path: "/sony/", MaxAge: 1209600})
+	writeJSON(w, http.StatusOK, response{Result: [0]struct{}{}, ID: req.ID})
+}
+
+// ExpireCookies invalidates the auth cookies of all registered clients, as if they had timed out
+func (tv *TV) ExpireCookies() {
+	tv.mu.Lock()
+	defer tv.mu.Unlock()
+
+	for id := range tv.clients {
+		tv.clients[id] = newCookie()
+	}
+}
+
+// newCookie returns a random auth cookie value
+func newCookie() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,454 @@
+// Package bravatest provides a fake Sony Bravia TV for tests and offline development.
+//
+// A TV keeps its state in memory and serves the JSON-RPC services and the IRCC SOAP
+// endpoint over HTTP, so it can be used with httptest or served on a real address:
+//
+//	srv := bravatest.NewServer(bravatest.WithPSK("secret"))
+//	defer srv.Close()
+//
+//	client := srv.APIClient()
+//	client.System.SetPowerStatus(ctx, false)
+package bravatest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/trugamr/bravia/api"
+)
+
+// Errors returned by the fake TV, mirroring the codes sent by real TVs
+var (
+	errIllegalArgument    = &api.Error{Code: api.ErrIllegalArgument.Code, Message: "Illegal Argument"}
+	errNoSuchMethod       = &api.Error{Code: api.ErrNoSuchMethod.Code, Message: "No Such Method"}
+	errUnsupportedVersion = &api.Error{Code: api.ErrUnsupportedVersion.Code, Message: "Unsupported Version"}
+	errUnauthorized       = &api.Error{Code: api.ErrUnauthorized.Code, Message: "Unauthorized"}
+	errForbidden          = &api.Error{Code: api.ErrForbidden.Code, Message: "Forbidden"}
+	errDisplayOff         = &api.Error{Code: api.ErrDisplayOff.Code, Message: "Display Is Turned off"}
+	errIllegalState       = &api.Error{Code: api.ErrIllegalState.Code, Message: "Illegal State"}
+)
+
+// Fault describes a failure injected into a method with TV.SetFault
+type Fault struct {
+	Err    *api.Error    // JSON-RPC error to respond with, if any
+	Status int           // HTTP status to respond with instead of a JSON-RPC response, if non-zero
+	Delay  time.Duration // Time to wait before responding
+	Times  int           // Number of calls the fault applies to, zero means every call
+}
+
+// TV is a stateful fake Bravia TV. It implements http.Handler and is safe for concurrent use.
+type TV struct {
+	mu sync.Mutex
+
+	psk       string
+	pin       string
+	clients   map[string]string // Auth cookie of each registered client ID
+	power     bool
+	macAddr   string
+	led       api.LEDIndicatorStatus
+	ledModes  []api.LEDIndicatorMode
+	saving    api.PowerSavingMode
+	savings   []api.PowerSavingMode
+	wol       bool
+	pictures  []api.PictureQualitySetting
+	sounds    []api.AudioSetting
+	speakers  []api.AudioSetting
+	scene     string
+	pip       string
+	volumes   []api.VolumeInfo
+	inputs    []api.ExternalInputStatus
+	channels  []api.ContentItem
+	apps      []api.Application
+	commands  []api.RemoteCommand
+	playing   string
+	activeApp string
+	sent      []string
+	faults    map[string]*Fault
+}
+
+// Option configures a TV created with New or NewServer
+type Option func(*TV)
+
+// WithPSK makes the TV require the given pre-shared key in the X-Auth-PSK header
+func WithPSK(psk string) Option {
+	return func(tv *TV) {
+		tv.psk = psk
+	}
+}
+
+// WithPIN sets the PIN the TV "shows on screen" when a client pairs, "0000" by default
+func WithPIN(pin string) Option {
+	return func(tv *TV) {
+		tv.pin = pin
+	}
+}
+
+// WithPower sets whether the TV starts powered on
+func WithPower(on bool) Option {
+	return func(tv *TV) {
+		tv.power = on
+	}
+}
+
+// WithApps replaces the applications installed on the TV
+func WithApps(apps ...api.Application) Option {
+	return func(tv *TV) {
+		tv.apps = apps
+	}
+}
+
+// WithInputs replaces the external inputs of the TV
+func WithInputs(inputs ...api.ExternalInputStatus) Option {
+	return func(tv *TV) {
+		tv.inputs = inputs
+	}
+}
+
+// WithChannels replaces the broadcast channels of the TV, their URIs start with the source, e.g. "tv:dvbt?"
+func WithChannels(channels ...api.ContentItem) Option {
+	return func(tv *TV) {
+		tv.channels = channels
+	}
+}
+
+// WithRemoteCommands replaces the IRCC codes the TV accepts
+func WithRemoteCommands(commands ...api.RemoteCommand) Option {
+	return func(tv *TV) {
+		tv.commands = commands
+	}
+}
+
+// WithPowerSavingModes replaces the power saving modes the TV accepts, others are
+// rejected with an "Illegal Argument" error like on models lacking them
+func WithPowerSavingModes(modes ...api.PowerSavingMode) Option {
+	return func(tv *TV) {
+		tv.savings = modes
+	}
+}
+
+// WithLEDIndicatorModes replaces the LED indicator modes the TV accepts
+func WithLEDIndicatorModes(modes ...api.LEDIndicatorMode) Option {
+	return func(tv *TV) {
+		tv.ledModes = modes
+	}
+}
+
+// New creates a fake TV that is powered on and has a few inputs, channels and apps
+func New(opts ...Option) *TV {
+	tv := &TV{
+		pin:      "0000",
+		clients:  make(map[string]string),
+		power:    true,
+		macAddr:  "02:00:00:00:00:01",
+		playing:  "extInput:hdmi?port=1",
+		led:      api.LEDIndicatorStatus{Mode: api.LEDIndicatorAutoBrightnessAdjust, Status: "true"},
+		ledModes: api.LEDIndicatorModes,
+		saving:   api.PowerSavingOff,
+		savings:  api.PowerSavingModes,
+		volumes: []api.VolumeInfo{
+			{Target: "speaker", Volume: 20, MinVolume: 0, MaxVolume: 100},
+			{Target: "headphone", Volume: 15, MinVolume: 0, MaxVolume: 100},
+		},
+		inputs: []api.ExternalInputStatus{
+			{URI: "extInput:hdmi?port=1", Title: "HDMI 1", Label: "Console", Icon: "meta:hdmi", Status: true},
+			{URI: "extInput:hdmi?port=2", Title: "HDMI 2", Icon: "meta:hdmi"},
+			{URI: "extInput:hdmi?port=3", Title: "HDMI 3/ARC", Label: "Soundbar", Icon: "meta:hdmi", Status: true},
+			{URI: "extInput:hdmi?port=4", Title: "HDMI 4", Icon: "meta:hdmi"},
+			{URI: "extInput:composite?port=1", Title: "AV", Icon: "meta:composite"},
+		},
+		apps: []api.Application{
+			{Title: "YouTube", URI: "com.sony.dtv.com.google.android.youtube.tv.com.google.android.apps.youtube.tv.activity.ShellActivity"},
+			{Title: "Netflix", URI: "com.sony.dtv.com.netflix.ninja.com.netflix.ninja.MainActivity"},
+			{Title: "Prime Video", URI: "com.sony.dtv.com.amazon.amazonvideo.livingroom.com.amazon.ignition.IgnitionActivity"},
+			{Title: "Settings", URI: "com.sony.dtv.com.android.tv.settings.com.android.tv.settings.MainSettings"},
+		},
+		channels: defaultChannels(),
+		commands: defaultRemoteCommands(),
+		pictures: defaultPictureSettings(),
+		sounds:   defaultSoundSettings(),
+		speakers: defaultSpeakerSettings(),
+		scene:    "auto",
+		pip:      "rightBottom",
+		faults:   make(map[string]*Fault),
+	}
+
+	for _, opt := range opts {
+		opt(tv)
+	}
+
+	return tv
+}
+
+// SetFault makes calls to the given method fail as described by fault.
+// The method is a JSON-RPC method name, or "X_SendIRCC" for the IRCC endpoint.
+func (tv *TV) SetFault(method string, fault Fault) {
+	tv.mu.Lock()
+	defer tv.mu.Unlock()
+
+	tv.faults[method] = &fault
+}
+
+// ClearFault removes the fault injected into the given method
+func (tv *TV) ClearFault(method string) {
+	tv.mu.Lock()
+	defer tv.mu.Unlock()
+
+	delete(tv.faults, method)
+}
+
+// Power reports whether the TV is powered on
+func (tv *TV) Power() bool {
+	tv.mu.Lock()
+	defer tv.mu.Unlock()
+
+	return tv.power
+}
+
+// Playing returns the URI of the content or input being played
+func (tv *TV) Playing() string {
+	tv.mu.Lock()
+	defer tv.mu.Unlock()
+
+	return tv.playing
+}
+
+// ActiveApp returns the URI of the application in the foreground, empty once an input is selected
+func (tv *TV) ActiveApp() string {
+	tv.mu.Lock()
+	defer tv.mu.Unlock()
+
+	return tv.activeApp
+}
+
+// SentCodes returns the IRCC codes received so far, in order
+func (tv *TV) SentCodes() []string {
+	tv.mu.Lock()
+	defer tv.mu.Unlock()
+
+	return slices.Clone(tv.sent)
+}
+
+// Volume returns the volume information for the given target
+func (tv *TV) Volume(target string) (api.VolumeInfo, bool) {
+	tv.mu.Lock()
+	defer tv.mu.Unlock()
+
+	for _, v := range tv.volumes {
+		if v.Target == target {
+			return v, true
+		}
+	}
+	return api.VolumeInfo{}, false
+}
+
+// ServeHTTP implements http.Handler
+func (tv *TV) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if r.URL.Path == irccPath {
+		tv.serveIRCC(w, r)
+		return
+	}
+
+	tv.serveJSONRPC(w, r)
+}
+
+// authorized reports whether the request carries the expected pre-shared key,
+// or the auth cookie of a registered client
+func (tv *TV) authorized(r *http.Request) bool {
+	if tv.psk == "" || r.Header.Get("X-Auth-PSK") == tv.psk {
+		return true
+	}
+
+	cookie, err := r.Cookie("auth")
+	if err != nil {
+		return false
+	}
+
+	tv.mu.Lock()
+	defer tv.mu.Unlock()
+
+	for _, value := range tv.clients {
+		if value == cookie.Value {
+			return true
+		}
+	}
+	return false
+}
+
+// fault returns the fault to apply to the method, consuming one use of it
+func (tv *TV) fault(method string) *Fault {
+	fault, ok := tv.faults[method]
+	if !ok {
+		return nil
+	}
+
+	if fault.Times > 0 {
+		fault.Times--
+		if fault.Times == 0 {
+			delete(tv.faults, method)
+		}
+	}
+
+	return fault
+}
+
+// request is a JSON-RPC request
+type request struct {
+	Method  string          `json:"method"`
+	ID      int             `json:"id"`
+	Params  json.RawMessage `json:"params"`
+	Version string          `json:"version"`
+}
+
+// response is a JSON-RPC response
+type response struct {
+	Result  interface{}     `json:"result,omitempty"`
+	Results interface{}     `json:"results,omitempty"`
+	Error   *[2]interface{} `json:"error,omitempty"`
+	ID      int             `json:"id"`
+}
+
+// serveJSONRPC dispatches a JSON-RPC request to the matching method of the service
+func (tv *TV) serveJSONRPC(w http.ResponseWriter, r *http.Request) {
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Registering doesn't require being authorized already
+	if r.URL.Path == accessControlPath {
+		tv.serveAccessControl(w, r, req)
+		return
+	}
+
+	if !tv.authorized(r) {
+		writeJSON(w, http.StatusForbidden, errorResponse(req.ID, errForbidden))
+		return
+	}
+
+	methods, ok := services[r.URL.Path]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	tv.mu.Lock()
+	fault := tv.fault(req.Method)
+	tv.mu.Unlock()
+
+	if fault != nil {
+		if !sleep(r, fault.Delay) {
+			return
+		}
+		if fault.Status != 0 {
+			w.WriteHeader(fault.Status)
+			return
+		}
+		if fault.Err != nil {
+			writeJSON(w, http.StatusOK, errorResponse(req.ID, fault.Err))
+			return
+		}
+	}
+
+	// getMethodTypes is the only method answering with "results"
+	if req.Method == "getMethodTypes" {
+		writeJSON(w, http.StatusOK, response{Results: methodTypes(methods), ID: req.ID})
+		return
+	}
+
+	m, ok := methods[req.Method]
+	if !ok {
+		writeJSON(w, http.StatusOK, errorResponse(req.ID, errNoSuchMethod))
+		return
+	}
+	if !slices.Contains(m.versions, req.Version) {
+		writeJSON(w, http.StatusOK, errorResponse(req.ID, errUnsupportedVersion))
+		return
+	}
+
+	tv.mu.Lock()
+	defer tv.mu.Unlock()
+
+	if m.needsPower && !tv.power {
+		writeJSON(w, http.StatusOK, errorResponse(req.ID, errDisplayOff))
+		return
+	}
+
+	result, err := m.handle(tv, req.Params, req.Version)
+	if err != nil {
+		writeJSON(w, http.StatusOK, errorResponse(req.ID, err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response{Result: result, ID: req.ID})
+}
+
+// errorResponse builds a JSON-RPC error response
+func errorResponse(id int, err *api.Error) response {
+	return response{Error: &[2]interface{}{err.Code, err.Message}, ID: id}
+}
+
+// writeJSON writes v as a JSON response with the given status code
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// sleep waits for d, returning false if the request was canceled first
+func sleep(r *http.Request, d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-r.Context().Done():
+		return false
+	}
+}
+
+// Server is a fake TV served by an httptest.Server
+type Server struct {
+	*httptest.Server
+	TV *TV
+}
+
+// NewServer starts a fake TV on a local httptest server. The caller should call Close when done.
+func NewServer(opts ...Option) *Server {
+	tv := New(opts...)
+	return &Server{
+		Server: httptest.NewServer(tv),
+		TV:     tv,
+	}
+}
+
+// APIClient returns an API client for the server configured with opts,
+// authenticated with its PSK if it has one
+func (s *Server) APIClient(opts ...api.Option) *api.Client {
+	baseURL, _ := url.Parse(s.URL)
+	client := api.NewClient(baseURL, opts...)
+
+	s.TV.mu.Lock()
+	psk := s.TV.psk
+	s.TV.mu.Unlock()
+
+	if psk != "" {
+		client = client.WithAuthPSK(psk)
+	}
+	return client
+}
@@ -0,0 +1,164 @@
+package bravatest
+
+import (
+	"encoding/xml"
+	"net/http"
+	"slices"
+
+	"github.com/trugamr/bravia/api"
+)
+
+// irccEnvelope is the SOAP envelope of an X_SendIRCC request
+type irccEnvelope struct {
+	Body struct {
+		SendIRCC struct {
+			IRCCCode string `xml:"IRCCCode"`
+		} `xml:"X_SendIRCC"`
+	} `xml:"Body"`
+}
+
+// irccFault is the SOAP fault sent for invalid IRCC codes, with a UPnP error code
+const irccFault = `<?xml version="1.0"?>
+<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">
+	<s:Body>
+		<s:Fault>
+			<faultcode>s:Client</faultcode>
+			<faultstring>UPnPError</faultstring>
+			<detail>
+				<UPnPError xmlns="urn:schemas-upnp-org:control-1-0">
+					<errorCode>800</errorCode>
+					<errorDescription>Invalid IRCC code</errorDescription>
+				</UPnPError>
+			</detail>
+		</s:Fault>
+	</s:Body>
+</s:Envelope>`
+
+// irccResponse is the SOAP response sent when an IRCC code was accepted
+const irccResponse = `<?xml version="1.0"?>
+<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">
+	<s:Body>
+		<u:X_SendIRCCResponse xmlns:u="urn:schemas-sony-com:service:IRCC:1"></u:X_SendIRCCResponse>
+	</s:Body>
+</s:Envelope>`
+
+// serveIRCC handles the IRCC SOAP endpoint
+func (tv *TV) serveIRCC(w http.ResponseWriter, r *http.Request) {
+	if !tv.authorized(r) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	var envelope irccEnvelope
+	if err := xml.NewDecoder(r.Body).Decode(&envelope); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	code := envelope.Body.SendIRCC.IRCCCode
+
+	tv.mu.Lock()
+	fault := tv.fault("X_SendIRCC")
+	tv.mu.Unlock()
+
+	if fault != nil {
+		if !sleep(r, fault.Delay) {
+			return
+		}
+		if fault.Status != 0 {
+			w.WriteHeader(fault.Status)
+			return
+		}
+	}
+
+	tv.mu.Lock()
+	defer tv.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/xml; charset=UTF-8")
+
+	if !slices.ContainsFunc(tv.commands, func(c api.RemoteCommand) bool { return c.Value == code }) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(irccFault))
+		return
+	}
+
+	tv.sent = append(tv.sent, code)
+
+	// Power related keys change the power state like on a real TV
+	switch api.IRCCCommand(code) {
+	case api.IRCCTvPower:
+		tv.power = !tv.power
+	case api.IRCCWakeUp:
+		tv.power = true
+	case api.IRCCPowerOff:
+		tv.power = false
+	}
+
+	w.Write([]byte(irccResponse))
+}
+
+// defaultRemoteCommands returns the IRCC codes accepted by a fake TV by default
+func defaultRemoteCommands() []api.RemoteCommand {
+	commands := []struct {
+		name string
+		code api.IRCCCommand
+	}{
+		{"PowerOff", api.IRCCPowerOff},
+		{"Input", api.IRCCInput},
+		{"Num1", api.IRCCNum1},
+		{"Num2", api.IRCCNum2},
+		{"Num3", api.IRCCNum3},
+		{"Num4", api.IRCCNum4},
+		{"Num5", api.IRCCNum5},
+		{"Num6", api.IRCCNum6},
+		{"Num7", api.IRCCNum7},
+		{"Num8", api.IRCCNum8},
+		{"Num9", api.IRCCNum9},
+		{"Num0", api.IRCCNum0},
+		{"Dot(.)", api.IRCCDOT},
+		{"SubTitle", api.IRCCSubTitle},
+		{"Red", api.IRCCRed},
+		{"Green", api.IRCCGreen},
+		{"Yellow", api.IRCCYellow},
+		{"Blue", api.IRCCBlue},
+		{"Up", api.IRCCUp},
+		{"Down", api.IRCCDown},
+		{"Right", api.IRCCRight},
+		{"Left", api.IRCCLeft},
+		{"Confirm", api.IRCCConfirm},
+		{"Help", api.IRCCHelp},
+		{"Display", api.IRCCDisplay},
+		{"Options", api.IRCCActionMenu},
+		{"Return", api.IRCCReturn},
+		{"Home", api.IRCCHome},
+		{"VolumeUp", api.IRCCVolumeUp},
+		{"VolumeDown", api.IRCCVolumeDown},
+		{"Mute", api.IRCCMute},
+		{"Audio", api.IRCCAudio},
+		{"ChannelUp", api.IRCCChannelUp},
+		{"ChannelDown", api.IRCCChannelDown},
+		{"Play", api.IRCCPlay},
+		{"Pause", api.IRCCPause},
+		{"Stop", api.IRCCStop},
+		{"Next", api.IRCCNext},
+		{"Prev", api.IRCCPrev},
+		{"Rewind", api.IRCCRewind},
+		{"Forward", api.IRCCForward},
+		{"Exit", api.IRCCExit},
+		{"Netflix", api.IRCCNetflix},
+		{"YouTube", api.IRCCYouTube},
+		{"Hdmi1", api.IRCCHdmi1},
+		{"Hdmi2", api.IRCCHdmi2},
+		{"Hdmi3", api.IRCCHdmi3},
+		{"TvPower", api.IRCCTvPower},
+		{"WakeUp", api.IRCCWakeUp},
+		{"Sleep", api.IRCCSleep},
+		{"ActionMenu", api.IRCCActionMenu},
+		{"ApplicationLauncher", api.IRCCApplicationLauncher},
+	}
+
+	remote := make([]api.RemoteCommand, 0, len(commands))
+	for _, c := range commands {
+		remote = append(remote, api.RemoteCommand{Name: c.name, Value: string(c.code)})
+	}
+	return remote
+}
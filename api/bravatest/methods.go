@@ -0,0 +1,709 @@
+package bravatest
+
+import (
+	"encoding/json"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trugamr/bravia/api"
+)
+
+const (
+	systemPath        = "/sony/system"
+	audioPath         = "/sony/audio"
+	avContentPath     = "/sony/avContent"
+	videoPath         = "/sony/video"
+	videoScreenPath   = "/sony/videoScreen"
+	appControlPath    = "/sony/appControl"
+	accessControlPath = "/sony/accessControl"
+	irccPath          = "/sony/ircc"
+)
+
+// handler handles a JSON-RPC method with the TV lock held, returning the result array
+type handler func(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error)
+
+// method describes a JSON-RPC method supported by the fake TV
+type method struct {
+	versions   []string // Supported versions
+	needsPower bool     // Whether the method fails with "display off" while in standby
+	handle     handler
+}
+
+// services maps each service path to the methods it supports
+var services = map[string]map[string]method{
+	systemPath: {
+		"getVersions":                {versions: v10, handle: versions("1.0")},
+		"setPowerStatus":             {versions: v10, handle: setPowerStatus},
+		"getPowerStatus":             {versions: v10, handle: getPowerStatus},
+		"getCurrentTime":             {versions: v10, handle: getCurrentTime},
+		"getSystemInformation":       {versions: v10, handle: getSystemInformation},
+		"getNetworkSettings":         {versions: v10, handle: getNetworkSettings},
+		"getSystemSupportedFunction": {versions: v10, handle: getSystemSupportedFunction},
+		"getRemoteControllerInfo":    {versions: v10, handle: getRemoteControllerInfo},
+		"getLEDIndicatorStatus":      {versions: v10, handle: getLEDIndicatorStatus},
+		"setLEDIndicatorStatus":      {versions: []string{"1.1"}, handle: setLEDIndicatorStatus},
+		"getPowerSavingMode":         {versions: v10, handle: getPowerSavingMode},
+		"setPowerSavingMode":         {versions: v10, handle: setPowerSavingMode},
+		"getWolMode":                 {versions: v10, handle: getWolMode},
+		"setWolMode":                 {versions: v10, handle: setWolMode},
+		"getInterfaceInformation":    {versions: v10, handle: getInterfaceInformation},
+		"requestReboot":              {versions: v10, handle: empty},
+	},
+	audioPath: {
+		"getVersions":          {versions: v10, handle: versions("1.0", "1.1", "1.2")},
+		"setAudioVolume":       {versions: []string{"1.0", "1.2"}, needsPower: true, handle: setAudioVolume},
+		"getVolumeInformation": {versions: v10, needsPower: true, handle: getVolumeInformation},
+		"setAudioMute":         {versions: v10, needsPower: true, handle: setAudioMute},
+		"getSoundSettings":     {versions: []string{"1.1"}, handle: getAudioSettings(soundSettings)},
+		"setSoundSettings":     {versions: []string{"1.1"}, needsPower: true, handle: setAudioSettings(soundSettings)},
+		"getSpeakerSettings":   {versions: v10, handle: getAudioSettings(speakerSettings)},
+		"setSpeakerSettings":   {versions: v10, needsPower: true, handle: setAudioSettings(speakerSettings)},
+	},
+	avContentPath: {
+		"getVersions":                    {versions: v10, handle: versions("1.0")},
+		"getCurrentExternalInputsStatus": {versions: v10, handle: getCurrentExternalInputsStatus},
+		"getPlayingContentInfo":          {versions: v10, needsPower: true, handle: getPlayingContentInfo},
+		"setPlayContent":                 {versions: v10, needsPower: true, handle: setPlayContent},
+		"getSchemeList":                  {versions: v10, handle: getSchemeList},
+		"getSourceList":                  {versions: v10, handle: getSourceList},
+		"getContentCount":                {versions: v10, handle: getContentCount},
+		"getContentList":                 {versions: v10, handle: getContentList},
+	},
+	videoPath: {
+		"getVersions":               {versions: v10, handle: versions("1.0")},
+		"getPictureQualitySettings": {versions: v10, handle: getPictureQualitySettings},
+		"setPictureQualitySettings": {versions: v10, needsPower: true, handle: setPictureQualitySettings},
+		"getScreenRotation":         {versions: v10, handle: getScreenRotation},
+	},
+	videoScreenPath: {
+		"getVersions":             {versions: v10, handle: versions("1.0")},
+		"setSceneSetting":         {versions: v10, needsPower: true, handle: setSceneSetting},
+		"setPipSubScreenPosition": {versions: v10, needsPower: true, handle: setPipSubScreenPosition},
+	},
+	appControlPath: {
+		"getVersions":        {versions: v10, handle: versions("1.0")},
+		"getApplicationList": {versions: v10, handle: getApplicationList},
+		"setActiveApp":       {versions: v10, needsPower: true, handle: setActiveApp},
+	},
+}
+
+// v10 is the version list of methods only available in version 1.0
+var v10 = []string{"1.0"}
+
+// methodTypes returns the getMethodTypes entries of a service, as [name, params, results, version]
+func methodTypes(methods map[string]method) [][]interface{} {
+	var names []string
+	for name := range methods {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	types := [][]interface{}{}
+	for _, name := range names {
+		for _, version := range methods[name].versions {
+			types = append(types, []interface{}{name, []string{}, []string{}, version})
+		}
+	}
+	return types
+}
+
+// decodeParams decodes the params array of a request into v
+func decodeParams(params json.RawMessage, v interface{}) *api.Error {
+	if err := json.Unmarshal(params, v); err != nil {
+		return errIllegalArgument
+	}
+	return nil
+}
+
+func versions(supported ...string) handler {
+	return func(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+		return [1][]string{supported}, nil
+	}
+}
+
+func empty(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	return [0]struct{}{}, nil
+}
+
+func setPowerStatus(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	var p [1]struct {
+		Status bool `json:"status"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	tv.power = p[0].Status
+	return [0]struct{}{}, nil
+}
+
+func getPowerStatus(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	status := "standby"
+	if tv.power {
+		status = "active"
+	}
+	return [1]api.PowerStatus{{Status: status}}, nil
+}
+
+func getCurrentTime(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	return [1]string{time.Now().Format("2006-01-02T15:04:05-0700")}, nil
+}
+
+func getSystemInformation(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	return [1]api.SystemInformation{{
+		Product:    "TV",
+		Region:     "XEU",
+		Language:   "eng",
+		Model:      "FAKE-BRAVIA",
+		Serial:     "0000001",
+		MACAddr:    tv.macAddr,
+		Name:       "BRAVIA",
+		Generation: "5.0.1",
+		Area:       "GBR",
+		CID:        "0000000000000000000000000000000000000000",
+	}}, nil
+}
+
+func getNetworkSettings(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	var p [1]struct {
+		Netif string `json:"netif"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, errIllegalArgument
+	}
+
+	settings := []api.NetworkSettings{{
+		Netif:    "eth0",
+		HWAddr:   tv.macAddr,
+		IPAddrV4: "192.168.1.50",
+		Netmask:  "255.255.255.0",
+		Gateway:  "192.168.1.1",
+		DNS:      []string{"192.168.1.1"},
+	}}
+	if p[0].Netif != "" && p[0].Netif != "eth0" {
+		settings = []api.NetworkSettings{}
+	}
+
+	return [1][]api.NetworkSettings{settings}, nil
+}
+
+func getSystemSupportedFunction(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	return [1][]api.SupportedFunction{{
+		{Option: "WOL", Value: tv.macAddr},
+		{Option: "pictureReverse", Value: "no"},
+	}}, nil
+}
+
+func getRemoteControllerInfo(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	return api.RemoteControllerInfo{Bundled: true, Type: "IR_REMOTE_BUNDLE_TYPE_AEP_N", Commands: tv.commands}, nil
+}
+
+func getLEDIndicatorStatus(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	return [1]api.LEDIndicatorStatus{tv.led}, nil
+}
+
+func setLEDIndicatorStatus(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	var p [1]struct {
+		Mode   api.LEDIndicatorMode `json:"mode"`
+		Status *string              `json:"status"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if !slices.Contains(tv.ledModes, p[0].Mode) {
+		return nil, errIllegalArgument
+	}
+
+	tv.led.Mode = p[0].Mode
+	if p[0].Status != nil {
+		tv.led.Status = *p[0].Status
+	}
+	return [0]struct{}{}, nil
+}
+
+func getPowerSavingMode(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	return [1]api.PowerSavingStatus{{Mode: tv.saving}}, nil
+}
+
+func setPowerSavingMode(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	var p [1]api.PowerSavingStatus
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if !slices.Contains(tv.savings, p[0].Mode) {
+		return nil, errIllegalArgument
+	}
+
+	tv.saving = p[0].Mode
+	return [0]struct{}{}, nil
+}
+
+func getWolMode(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	return [1]api.WolMode{{Enabled: tv.wol}}, nil
+}
+
+func setWolMode(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	var p [1]api.WolMode
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	tv.wol = p[0].Enabled
+	return [0]struct{}{}, nil
+}
+
+func getInterfaceInformation(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	return [1]api.InterfaceInformation{{
+		ProductCategory:  "tv",
+		ProductName:      "BRAVIA",
+		ModelName:        "FAKE-BRAVIA",
+		InterfaceVersion: "5.0.1",
+	}}, nil
+}
+
+func setAudioVolume(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	var p [1]struct {
+		Volume string `json:"volume"`
+		Target string `json:"target"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	for i := range tv.volumes {
+		v := &tv.volumes[i]
+		if p[0].Target != "" && v.Target != p[0].Target {
+			continue
+		}
+
+		level, err := strconv.Atoi(p[0].Volume)
+		if err != nil {
+			return nil, errIllegalArgument
+		}
+		// Relative changes are signed, absolute levels are not
+		if strings.HasPrefix(p[0].Volume, "+") || strings.HasPrefix(p[0].Volume, "-") {
+			level += v.Volume
+		}
+		v.Volume = min(max(level, v.MinVolume), v.MaxVolume)
+	}
+
+	return [0]struct{}{}, nil
+}
+
+func getVolumeInformation(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	return [1][]api.VolumeInfo{slices.Clone(tv.volumes)}, nil
+}
+
+func setAudioMute(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	var p [1]struct {
+		Status bool `json:"status"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	for i := range tv.volumes {
+		tv.volumes[i].Mute = p[0].Status
+	}
+	return [0]struct{}{}, nil
+}
+
+// soundSettings returns the sound settings of the TV, for the audio settings handlers
+func soundSettings(tv *TV) []api.AudioSetting {
+	return tv.sounds
+}
+
+// speakerSettings returns the speaker settings of the TV, for the audio settings handlers
+func speakerSettings(tv *TV) []api.AudioSetting {
+	return tv.speakers
+}
+
+// getAudioSettings returns a handler listing the sound or speaker settings returned by settings
+func getAudioSettings(settings func(tv *TV) []api.AudioSetting) handler {
+	return func(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+		var p [1]struct {
+			Target string `json:"target"`
+		}
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+
+		matching := []api.AudioSetting{}
+		for _, setting := range settings(tv) {
+			if p[0].Target == "" || setting.Target == p[0].Target {
+				matching = append(matching, setting)
+			}
+		}
+		return [1][]api.AudioSetting{matching}, nil
+	}
+}
+
+// setAudioSettings returns a handler changing the sound or speaker settings returned by settings
+func setAudioSettings(settings func(tv *TV) []api.AudioSetting) handler {
+	return func(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+		var p [1]struct {
+			Settings []api.AudioSettingValue `json:"settings"`
+		}
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+
+		// Check every value before changing anything, like the TV does
+		current := settings(tv)
+		indexes := make([]int, len(p[0].Settings))
+		for i, value := range p[0].Settings {
+			index := slices.IndexFunc(current, func(setting api.AudioSetting) bool {
+				return setting.Target == value.Target
+			})
+			if index < 0 {
+				return nil, errIllegalArgument
+			}
+			if _, err := current[index].Validate(value.Value); err != nil {
+				return nil, errIllegalArgument
+			}
+			indexes[i] = index
+		}
+
+		for i, value := range p[0].Settings {
+			current[indexes[i]].CurrentValue = value.Value
+		}
+		return [0]struct{}{}, nil
+	}
+}
+
+func getCurrentExternalInputsStatus(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	return [1][]api.ExternalInputStatus{slices.Clone(tv.inputs)}, nil
+}
+
+func setPlayContent(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	var p [1]struct {
+		URI string `json:"uri"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	if !slices.ContainsFunc(tv.content(), func(item api.ContentItem) bool { return item.URI == p[0].URI }) {
+		return nil, errIllegalArgument
+	}
+
+	tv.playing = p[0].URI
+	tv.activeApp = ""
+	return [0]struct{}{}, nil
+}
+
+func getPlayingContentInfo(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	// Apps in the foreground don't report what they play
+	if tv.activeApp != "" || tv.playing == "" {
+		return nil, errIllegalState
+	}
+
+	content := tv.content()
+	index := slices.IndexFunc(content, func(item api.ContentItem) bool { return item.URI == tv.playing })
+	if index < 0 {
+		return nil, errIllegalState
+	}
+
+	item := content[index]
+	source, _, _ := strings.Cut(item.URI, "?")
+	info := api.PlayingContentInfo{URI: item.URI, Source: source, Title: item.Title}
+	if item.DispNum != nil {
+		info.DispNum = *item.DispNum
+		info.OriginalDispNum = *item.DispNum
+		info.TripletStr = *item.TripletStr
+		info.MediaType = *item.ProgramMediaType
+	}
+	return [1]api.PlayingContentInfo{info}, nil
+}
+
+// content returns the inputs and channels of the TV as content items
+func (tv *TV) content() []api.ContentItem {
+	items := make([]api.ContentItem, 0, len(tv.inputs)+len(tv.channels))
+	for _, input := range tv.inputs {
+		items = append(items, api.ContentItem{URI: input.URI, Title: input.Title})
+	}
+	return append(items, tv.channels...)
+}
+
+func getSchemeList(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	schemes := []api.Scheme{}
+	for _, item := range tv.content() {
+		scheme, _, _ := strings.Cut(item.URI, ":")
+		if !slices.Contains(schemes, api.Scheme{Scheme: scheme}) {
+			schemes = append(schemes, api.Scheme{Scheme: scheme})
+		}
+	}
+	return [1][]api.Scheme{schemes}, nil
+}
+
+func getSourceList(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	var p [1]struct {
+		Scheme string `json:"scheme"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	var sources []api.Source
+	for _, item := range tv.content() {
+		source, _, _ := strings.Cut(item.URI, "?")
+		if strings.HasPrefix(source, p[0].Scheme+":") && !slices.Contains(sources, api.Source{Source: source}) {
+			sources = append(sources, api.Source{Source: source})
+		}
+	}
+	if len(sources) == 0 {
+		return nil, errIllegalArgument
+	}
+	return [1][]api.Source{sources}, nil
+}
+
+// contentItems returns the content items of a source
+func (tv *TV) contentItems(source string) []api.ContentItem {
+	items := []api.ContentItem{}
+	for _, item := range tv.content() {
+		if strings.HasPrefix(item.URI, source+"?") {
+			item.Index = len(items)
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func getContentCount(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	var p [1]struct {
+		Source string `json:"source"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	return [1]api.ContentCount{{Count: len(tv.contentItems(p[0].Source))}}, nil
+}
+
+func getContentList(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	var p [1]struct {
+		Source string `json:"source"`
+		StIdx  *int   `json:"stIdx"`
+		Cnt    *int   `json:"cnt"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	items := tv.contentItems(p[0].Source)
+
+	// Like real TVs, return at most 50 items per page
+	start, count := 0, 50
+	if p[0].StIdx != nil {
+		start = *p[0].StIdx
+	}
+	if p[0].Cnt != nil {
+		count = min(*p[0].Cnt, 50)
+	}
+	// Like some real TVs, reject a start index past the last item
+	if start < 0 || count < 0 || (start > 0 && start >= len(items)) {
+		return nil, errIllegalArgument
+	}
+
+	end := min(start+count, len(items))
+	return [1][]api.ContentItem{items[start:end]}, nil
+}
+
+func getPictureQualitySettings(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	var p [1]struct {
+		Target string `json:"target"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	settings := []api.PictureQualitySetting{}
+	for _, setting := range tv.pictures {
+		if p[0].Target == "" || setting.Target == p[0].Target {
+			settings = append(settings, setting)
+		}
+	}
+	return [1][]api.PictureQualitySetting{settings}, nil
+}
+
+func setPictureQualitySettings(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	var p [1]struct {
+		Settings []api.PictureQualityValue `json:"settings"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	// Check every value before changing anything, like the TV does
+	indexes := make([]int, len(p[0].Settings))
+	for i, value := range p[0].Settings {
+		index := slices.IndexFunc(tv.pictures, func(setting api.PictureQualitySetting) bool {
+			return setting.Target == value.Target
+		})
+		if index < 0 {
+			return nil, errIllegalArgument
+		}
+		if _, err := tv.pictures[index].Validate(value.Value); err != nil {
+			return nil, errIllegalArgument
+		}
+		indexes[i] = index
+	}
+
+	for i, value := range p[0].Settings {
+		tv.pictures[indexes[i]].CurrentValue = value.Value
+	}
+	return [0]struct{}{}, nil
+}
+
+func getScreenRotation(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	return [1]api.ScreenRotation{{Angle: 0}}, nil
+}
+
+func setSceneSetting(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	var p [1]struct {
+		Value string `json:"value"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if !slices.Contains([]string{"auto", "auto24pSync", "general"}, p[0].Value) {
+		return nil, errIllegalArgument
+	}
+
+	tv.scene = p[0].Value
+	return [0]struct{}{}, nil
+}
+
+func setPipSubScreenPosition(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	var p [1]struct {
+		Position string `json:"position"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if !slices.Contains([]string{"leftTop", "rightTop", "leftBottom", "rightBottom"}, p[0].Position) {
+		return nil, errIllegalArgument
+	}
+
+	tv.pip = p[0].Position
+	return [0]struct{}{}, nil
+}
+
+func getApplicationList(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	return [1][]api.Application{slices.Clone(tv.apps)}, nil
+}
+
+func setActiveApp(tv *TV, params json.RawMessage, version string) (interface{}, *api.Error) {
+	var p [1]struct {
+		URI string `json:"uri"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	if !slices.ContainsFunc(tv.apps, func(app api.Application) bool { return app.URI == p[0].URI }) {
+		return nil, errIllegalArgument
+	}
+
+	tv.activeApp = p[0].URI
+	return [0]struct{}{}, nil
+}
+
+// defaultPictureSettings returns the picture quality settings of a typical TV
+func defaultPictureSettings() []api.PictureQualitySetting {
+	level := func(target, current string, max float64) api.PictureQualitySetting {
+		return api.PictureQualitySetting{
+			Target:       target,
+			CurrentValue: current,
+			IsAvailable:  true,
+			Candidate:    []api.PictureQualityCandidate{{Min: 0, Max: max, Step: 1}},
+		}
+	}
+	named := func(target, current string, values ...string) api.PictureQualitySetting {
+		setting := api.PictureQualitySetting{Target: target, CurrentValue: current, IsAvailable: true}
+		for _, value := range values {
+			setting.Candidate = append(setting.Candidate, api.PictureQualityCandidate{Value: value})
+		}
+		return setting
+	}
+
+	return []api.PictureQualitySetting{
+		named(api.PictureMode, "standard", "vivid", "standard", "cinema", "custom", "game", "graphics"),
+		level(api.PictureBrightness, "40", 50),
+		level(api.PictureContrast, "90", 100),
+		level(api.PictureColor, "50", 100),
+		level(api.PictureSharpness, "50", 100),
+		named(api.PictureColorTemperature, "neutral", "cool", "neutral", "warm1", "warm2"),
+		named(api.PictureMotionflow, "standard", "off", "standard", "smooth", "clear", "trueCinema"),
+		named(api.PictureHDRMode, "auto", "off", "auto", "hdr10", "hlg"),
+	}
+}
+
+// defaultSoundSettings returns the sound settings of a TV with a soundbar on HDMI ARC
+func defaultSoundSettings() []api.AudioSetting {
+	return []api.AudioSetting{{
+		Target:       api.SoundOutputTerminal,
+		CurrentValue: api.OutputTerminalSpeaker,
+		Candidate: []api.AudioCandidate{
+			{Candidate: api.Candidate{Value: api.OutputTerminalSpeaker}, Title: "TV Speakers", IsAvailable: true},
+			{Candidate: api.Candidate{Value: api.OutputTerminalSpeakerHDMI}, Title: "TV Speakers and HDMI", IsAvailable: true},
+			{Candidate: api.Candidate{Value: api.OutputTerminalHDMI}, Title: "HDMI ARC", IsAvailable: true},
+			{Candidate: api.Candidate{Value: api.OutputTerminalAudioSystem}, Title: "Audio System", IsAvailable: false},
+		},
+	}}
+}
+
+// defaultSpeakerSettings returns the speaker settings of a typical TV
+func defaultSpeakerSettings() []api.AudioSetting {
+	return []api.AudioSetting{
+		{
+			Target:       api.SpeakerTVPosition,
+			CurrentValue: api.TVPositionTableTop,
+			Candidate: []api.AudioCandidate{
+				{Candidate: api.Candidate{Value: api.TVPositionTableTop}, Title: "Table Top", IsAvailable: true},
+				{Candidate: api.Candidate{Value: api.TVPositionWallMount}, Title: "Wall Mount", IsAvailable: true},
+			},
+		},
+		{
+			Target:       api.SpeakerSubwooferLevel,
+			CurrentValue: "12",
+			Candidate:    []api.AudioCandidate{{Candidate: api.Candidate{Min: 0, Max: 24, Step: 1}, IsAvailable: true}},
+		},
+		{
+			Target:       api.SpeakerSoundField,
+			CurrentValue: "standard",
+			Candidate: []api.AudioCandidate{
+				{Candidate: api.Candidate{Value: "standard"}, Title: "Standard", IsAvailable: true},
+				{Candidate: api.Candidate{Value: "cinema"}, Title: "Cinema", IsAvailable: true},
+				{Candidate: api.Candidate{Value: "music"}, Title: "Music", IsAvailable: true},
+			},
+		},
+	}
+}
+
+// defaultChannels returns the DVB-T channels of a typical TV
+func defaultChannels() []api.ContentItem {
+	channel := func(dispNum, name, triplet, mediaType string) api.ContentItem {
+		return api.ContentItem{
+			URI:              "tv:dvbt?trip=" + triplet + "&srvName=" + url.PathEscape(name),
+			Title:            name,
+			DispNum:          &dispNum,
+			TripletStr:       &triplet,
+			ProgramMediaType: &mediaType,
+			ChannelName:      &name,
+		}
+	}
+
+	return []api.ContentItem{
+		channel("001", "BBC One", "9018.4165.4165", "tv"),
+		channel("002", "BBC Two", "9018.4229.4229", "tv"),
+		channel("003", "ITV1", "9018.8197.8197", "tv"),
+		channel("004", "Channel 4", "9018.8261.8261", "tv"),
+		channel("005", "Channel 5", "9018.8325.8325", "tv"),
+		channel("050", "BBC News", "9018.4287.4287", "tv"),
+		channel("700", "BBC Radio 1", "9018.4608.4608", "radio"),
+	}
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Candidate represents an accepted value of a setting, such as a picture quality or sound
+// setting, either a named value or a numeric range
+type Candidate struct {
+	Value string  `json:"value,omitempty"` // Named value, e.g. "vivid", empty for a range
+	Max   float64 `json:"max,omitempty"`
+	Min   float64 `json:"min,omitempty"`
+	Step  float64 `json:"step,omitempty"`
+}
+
+// IsRange reports whether the candidate is a numeric range rather than a named value
+func (c Candidate) IsRange() bool {
+	return c.Value == ""
+}
+
+// String returns the named value, or the range as "min-max"
+func (c Candidate) String() string {
+	if !c.IsRange() {
+		return c.Value
+	}
+	return fmt.Sprintf("%g-%g", c.Min, c.Max)
+}
+
+// Match reports whether the candidate accepts value, matching named values regardless of case,
+// and returns value as accepted by the candidate
+func (c Candidate) Match(value string) (string, bool) {
+	if !c.IsRange() {
+		return c.Value, strings.EqualFold(c.Value, value)
+	}
+	return value, inRange(value, c.Min, c.Max, c.Step)
+}
+
+// matchCandidate returns the index of the first candidate accepting value and value as accepted
+// by it. Values no candidate accepts are reported as a *ModeError listing the candidates.
+// Settings without candidates accept any value, with an index of -1.
+func matchCandidate(setting, value string, candidates []Candidate) (int, string, error) {
+	// Settings without candidates accept anything, let the TV decide
+	if len(candidates) == 0 {
+		return -1, value, nil
+	}
+
+	accepted := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		if matched, ok := candidate.Match(value); ok {
+			return i, matched, nil
+		}
+		accepted[i] = candidate.String()
+	}
+	return -1, "", &ModeError{Setting: setting, Mode: value, Accepted: accepted}
+}
+
+// inRange reports whether value is a number within min and max, and on one of the steps from min
+func inRange(value string, min, max, step float64) bool {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < min || n > max {
+		return false
+	}
+	if step <= 0 {
+		return true
+	}
+
+	steps := (n - min) / step
+	return math.Abs(steps-math.Round(steps)) < 1e-9
+}
@@ -0,0 +1,76 @@
+package api_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/trugamr/bravia/api"
+)
+
+func TestSettingValidate(t *testing.T) {
+	picture := api.PictureQualitySetting{
+		Target:      "brightness",
+		IsAvailable: true,
+		Candidate: []api.PictureQualityCandidate{
+			{Min: 0, Max: 50, Step: 2},
+			{Value: "auto"},
+		},
+	}
+	sound := api.AudioSetting{
+		Target: "outputTerminal",
+		Candidate: []api.AudioCandidate{
+			{Candidate: api.Candidate{Min: 0, Max: 50, Step: 2}, IsAvailable: true},
+			{Candidate: api.Candidate{Value: "auto"}, IsAvailable: true},
+			{Candidate: api.Candidate{Value: "audioSystem"}, IsAvailable: false},
+		},
+	}
+
+	// Both services accept the same values under the same range and step rules
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"24", "24", false},
+		{"50", "50", false},
+		{"25", "", true},
+		{"52", "", true},
+		{"AUTO", "auto", false},
+		{"vivid", "", true},
+	}
+
+	validators := map[string]func(string) (string, error){
+		"picture": picture.Validate,
+		"sound":   sound.Validate,
+	}
+	for name, validate := range validators {
+		for _, tt := range tests {
+			got, err := validate(tt.value)
+			if tt.wantErr {
+				var modeErr *api.ModeError
+				if !errors.As(err, &modeErr) {
+					t.Errorf("%s Validate(%q) error = %v, want a *api.ModeError", name, tt.value, err)
+				}
+				continue
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("%s Validate(%q) = %q, %v, want %q", name, tt.value, got, err, tt.want)
+			}
+		}
+	}
+
+	if _, err := sound.Validate("audioSystem"); !errors.Is(err, api.ErrIllegalState) {
+		t.Errorf("sound Validate(audioSystem) error = %v, want %v", err, api.ErrIllegalState)
+	}
+
+	// Settings without candidates accept anything
+	empty := map[string]func(string) (string, error){
+		"picture": api.PictureQualitySetting{Target: "brightness", IsAvailable: true}.Validate,
+		"sound":   api.AudioSetting{Target: "soundField"}.Validate,
+	}
+	for name, validate := range empty {
+		if got, err := validate("anything"); err != nil || got != "anything" {
+			t.Errorf("%s Validate(anything) without candidates = %q, %v, want it accepted", name, got, err)
+		}
+	}
+}
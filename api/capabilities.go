@@ -0,0 +1,208 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// servicePaths lists the service paths probed by Client.Capabilities
+var servicePaths = []string{systemPath, audioPath, avContentPath, videoPath, videoScreenPath, appControlPath, accessControlPath}
+
+// Capabilities describes the API methods supported by a TV, keyed by service path
+type Capabilities map[string]ServiceCapabilities
+
+// ServiceCapabilities describes the versions and methods supported by a single service
+type ServiceCapabilities struct {
+	Versions []string            `json:"versions"` // Versions of the service
+	Methods  map[string][]string `json:"methods"`  // Supported versions of each method, keyed by method name
+}
+
+// capabilityCache caches the capabilities of each service once they have been fetched
+type capabilityCache struct {
+	mu sync.Mutex
+	// services maps a service path to its capabilities, a nil value means the
+	// service doesn't support getVersions/getMethodTypes
+	services map[string]*ServiceCapabilities
+}
+
+// WithVersionNegotiation returns a new client that asks the TV which method versions it supports
+// and calls each method with the highest version known to both sides
+func (c *Client) WithVersionNegotiation() *Client {
+	clone := c.copy()
+	defer clone.initialize()
+
+	clone.negotiate = true
+
+	return clone
+}
+
+// Capabilities returns the methods and versions supported by each service of the TV.
+// Services that don't support discovery are left out. Results are cached by the client.
+func (c *Client) Capabilities(ctx context.Context) (Capabilities, error) {
+	capabilities := make(Capabilities, len(servicePaths))
+	for _, path := range servicePaths {
+		service, err := c.serviceCapabilities(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		if service != nil {
+			capabilities[path] = *service
+		}
+	}
+
+	return capabilities, nil
+}
+
+// version returns the version to call a method with, given the versions supported by the
+// client in ascending order. Without negotiation, the first version is always used.
+func (c *Client) version(ctx context.Context, path, method string, versions ...string) (string, error) {
+	if !c.negotiate {
+		return versions[0], nil
+	}
+
+	service, err := c.serviceCapabilities(ctx, path)
+	if err != nil {
+		return "", err
+	}
+
+	// The TV can't tell us what it supports, so stick with the baseline version
+	if service == nil {
+		return versions[0], nil
+	}
+
+	supported, ok := service.Methods[method]
+	if !ok {
+		return "", &UnsupportedError{Path: path, Method: method}
+	}
+
+	best := ""
+	for _, version := range versions {
+		if slices.Contains(supported, version) && (best == "" || compareVersions(version, best) > 0) {
+			best = version
+		}
+	}
+	if best == "" {
+		return "", &UnsupportedError{Path: path, Method: method, Versions: supported}
+	}
+
+	return best, nil
+}
+
+// serviceCapabilities returns the cached capabilities of the service at path, fetching them if needed
+func (c *Client) serviceCapabilities(ctx context.Context, path string) (*ServiceCapabilities, error) {
+	c.capabilities.mu.Lock()
+	defer c.capabilities.mu.Unlock()
+
+	if service, ok := c.capabilities.services[path]; ok {
+		return service, nil
+	}
+
+	service, err := c.fetchServiceCapabilities(ctx, path)
+	if err != nil {
+		// Older firmware doesn't support discovery, remember that instead of asking every time
+		if !IsUnsupported(err) {
+			return nil, err
+		}
+		service = nil
+	}
+
+	if c.capabilities.services == nil {
+		c.capabilities.services = make(map[string]*ServiceCapabilities)
+	}
+	c.capabilities.services[path] = service
+
+	return service, nil
+}
+
+// methodTypesResult is the response from the getMethodTypes method, which unlike
+// other methods returns its entries in a "results" field
+type methodTypesResult struct {
+	Error   *[2]interface{} `json:"error,omitempty"`
+	Results [][]interface{} `json:"results,omitempty"`
+	ID      int             `json:"id"`
+}
+
+// fetchServiceCapabilities calls getVersions and getMethodTypes on the service at path
+func (c *Client) fetchServiceCapabilities(ctx context.Context, path string) (*ServiceCapabilities, error) {
+	versions, _, err := Call[[1][]string](ctx, c, path, "getVersions", "1.0", [0]struct{}{})
+	if err != nil {
+		return nil, err
+	}
+	if versions.Result == nil {
+		return nil, fmt.Errorf("bravia: getVersions of %s returned no result", path)
+	}
+
+	// An empty version asks for the methods of all versions at once
+	body := Payload[[1]string]{
+		Method:  "getMethodTypes",
+		ID:      c.nextID(),
+		Params:  [1]string{""},
+		Version: "1.0",
+	}
+
+	req, err := c.NewRequest(http.MethodPost, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(methodTypesResult)
+	_, err = c.Do(ctx, req, result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, newError(*result.Error)
+	}
+
+	service := &ServiceCapabilities{
+		Versions: (*versions.Result)[0],
+		Methods:  make(map[string][]string),
+	}
+
+	// Each entry has the form [name, parameterTypes, resultTypes, version]
+	for _, entry := range result.Results {
+		if len(entry) < 4 {
+			continue
+		}
+		name, ok := entry[0].(string)
+		if !ok {
+			continue
+		}
+		version, ok := entry[3].(string)
+		if !ok {
+			continue
+		}
+		if !slices.Contains(service.Methods[name], version) {
+			service.Methods[name] = append(service.Methods[name], version)
+		}
+	}
+
+	return service, nil
+}
+
+// compareVersions compares two dotted version strings numerically,
+// returning -1, 0 or +1 like strings.Compare
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
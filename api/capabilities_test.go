@@ -0,0 +1,110 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/trugamr/bravia/api"
+	"github.com/trugamr/bravia/api/bravatest"
+)
+
+func TestCapabilities(t *testing.T) {
+	srv := bravatest.NewServer(bravatest.WithPSK("secret"))
+	defer srv.Close()
+
+	capabilities, err := srv.APIClient().Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("Capabilities() error = %v", err)
+	}
+
+	audio, ok := capabilities["/sony/audio"]
+	if !ok {
+		t.Fatal("Capabilities() is missing /sony/audio")
+	}
+	if got := audio.Methods["setAudioVolume"]; len(got) != 2 {
+		t.Errorf("setAudioVolume versions = %v, want 1.0 and 1.2", got)
+	}
+}
+
+func TestCapabilitiesTransientErrorNotCached(t *testing.T) {
+	tests := []struct {
+		name  string
+		fault bravatest.Fault
+	}{
+		{"http status", bravatest.Fault{Status: http.StatusServiceUnavailable, Times: 1}},
+		{"display off", bravatest.Fault{Err: api.ErrDisplayOff, Times: 1}},
+		{"illegal state", bravatest.Fault{Err: api.ErrIllegalState, Times: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := bravatest.NewServer(bravatest.WithPSK("secret"))
+			defer srv.Close()
+
+			client := srv.APIClient()
+			srv.TV.SetFault("getVersions", tt.fault)
+
+			if _, err := client.Capabilities(context.Background()); err == nil {
+				t.Fatal("Capabilities() error = nil, want the injected fault")
+			}
+
+			capabilities, err := client.Capabilities(context.Background())
+			if err != nil {
+				t.Fatalf("Capabilities() after the fault error = %v", err)
+			}
+			if _, ok := capabilities["/sony/system"]; !ok {
+				t.Error("Capabilities() left out /sony/system after a transient error")
+			}
+		})
+	}
+}
+
+func TestCapabilitiesNoDiscoveryCached(t *testing.T) {
+	srv := bravatest.NewServer(bravatest.WithPSK("secret"))
+	defer srv.Close()
+
+	client := srv.APIClient()
+	srv.TV.SetFault("getVersions", bravatest.Fault{Err: api.ErrNoSuchMethod})
+
+	capabilities, err := client.Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("Capabilities() error = %v", err)
+	}
+	if len(capabilities) != 0 {
+		t.Errorf("Capabilities() = %v, want no services", capabilities)
+	}
+
+	// Firmware without discovery is remembered, the TV isn't asked again
+	srv.TV.ClearFault("getVersions")
+	capabilities, err = client.Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("Capabilities() error = %v", err)
+	}
+	if len(capabilities) != 0 {
+		t.Errorf("Capabilities() = %v, want no services", capabilities)
+	}
+
+	// Without discovery, methods are called with their baseline version
+	negotiated := client.WithVersionNegotiation()
+	if _, _, err := negotiated.System.GetPowerStatus(context.Background()); err != nil {
+		t.Errorf("GetPowerStatus() error = %v", err)
+	}
+}
+
+func TestCapabilitiesMissingResult(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer srv.Close()
+
+	baseURL, _ := url.Parse(srv.URL)
+	client := api.NewClient(baseURL)
+
+	if _, err := client.Capabilities(context.Background()); err == nil {
+		t.Error("Capabilities() error = nil, want an error for the missing result")
+	}
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lithammer/fuzzysearch/fuzzy"
+)
+
+// channelScheme is the content scheme of broadcast channels, e.g. "tv:dvbt" or "tv:isdbt"
+const channelScheme = "tv"
+
+// ErrChannelNotFound is returned when no channel matches the number or name looked up
+var ErrChannelNotFound = errors.New("bravia: channel not found")
+
+// Channel represents a broadcast channel
+type Channel struct {
+	URI        string `json:"uri"`
+	Source     string `json:"source"`               // Source of the channel, e.g. "tv:dvbt"
+	DispNum    string `json:"dispNum"`              // Channel number as shown by the TV
+	Name       string `json:"name"`                 // Channel name, e.g. "BBC One"
+	TripletStr string `json:"tripletStr,omitempty"` // DVB triplet of the channel
+	MediaType  string `json:"mediaType,omitempty"`  // Media type, e.g. "tv" or "radio"
+}
+
+// Channels returns the broadcast channels of every tv source, such as "tv:dvbt" and "tv:dvbc".
+// TVs without a tuner, or without a channel scan, return no channels.
+func (s *AVContentService) Channels(ctx context.Context) ([]Channel, error) {
+	sources, _, err := s.GetSourceList(ctx, channelScheme)
+	if errors.Is(err, ErrIllegalArgument) {
+		// The TV doesn't know the tv scheme
+		return []Channel{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	channels := []Channel{}
+	for _, source := range (*sources.Result)[0] {
+		for item, err := range s.ContentItems(ctx, source.Source) {
+			if err != nil {
+				return nil, err
+			}
+			channels = append(channels, newChannel(source.Source, item))
+		}
+	}
+	return channels, nil
+}
+
+// newChannel returns the channel described by a content item of source
+func newChannel(source string, item ContentItem) Channel {
+	channel := Channel{URI: item.URI, Source: source, Name: item.Title}
+	if item.ChannelName != nil && *item.ChannelName != "" {
+		channel.Name = *item.ChannelName
+	}
+	if item.DispNum != nil {
+		channel.DispNum = *item.DispNum
+	}
+	if item.TripletStr != nil {
+		channel.TripletStr = *item.TripletStr
+	}
+	if item.ProgramMediaType != nil {
+		channel.MediaType = *item.ProgramMediaType
+	}
+	return channel
+}
+
+// ChannelIndex is a list of channels to look up by number or name, which can be kept
+// as JSON to save scanning the channels of the TV every time
+type ChannelIndex struct {
+	Channels []Channel `json:"channels"`
+	Updated  time.Time `json:"updated"` // When the channels were read from the TV
+}
+
+// NewChannelIndex reads the channels of the TV into an index
+func (s *AVContentService) NewChannelIndex(ctx context.Context) (*ChannelIndex, error) {
+	channels, err := s.Channels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ChannelIndex{Channels: channels, Updated: time.Now()}, nil
+}
+
+// Find returns the channel numbered query, ignoring leading zeros, or else the channel
+// whose name matches query best. Names are matched fuzzily, so "bbcnews" finds "BBC News".
+func (x *ChannelIndex) Find(query string) (Channel, error) {
+	if strings.TrimSpace(query) == "" {
+		return Channel{}, fmt.Errorf("%w: no channel given", ErrChannelNotFound)
+	}
+
+	number := strings.TrimLeft(query, "0")
+	for _, channel := range x.Channels {
+		if channel.DispNum != "" && strings.TrimLeft(channel.DispNum, "0") == number {
+			return channel, nil
+		}
+	}
+
+	names := make([]string, len(x.Channels))
+	for i, channel := range x.Channels {
+		names[i] = channel.Name
+	}
+
+	matches := fuzzy.RankFindFold(query, names)
+	if len(matches) == 0 {
+		return Channel{}, fmt.Errorf("%w matching %q", ErrChannelNotFound, query)
+	}
+	sort.Sort(matches)
+
+	return x.Channels[matches[0].OriginalIndex], nil
+}
@@ -0,0 +1,118 @@
+package discovery
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// description is the subset of a UPnP device description used to describe a TV
+type description struct {
+	Device struct {
+		FriendlyName string `xml:"friendlyName"`
+		Manufacturer string `xml:"manufacturer"`
+		ModelName    string `xml:"modelName"`
+		UDN          string `xml:"UDN"`
+		MACAddress   string `xml:"X_MacAddress"`
+		ScalarWebAPI struct {
+			BaseURL string `xml:"X_ScalarWebAPI_BaseURL"`
+		} `xml:"X_ScalarWebAPI_DeviceInfo"`
+		Services []struct {
+			ServiceType string `xml:"serviceType"`
+			ControlURL  string `xml:"controlURL"`
+		} `xml:"serviceList>service"`
+	} `xml:"device"`
+}
+
+// udnMACPattern matches version 1 UUIDs, which end with the MAC address of the device
+var udnMACPattern = regexp.MustCompile(`^uuid:[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-1[0-9a-fA-F]{3}-[0-9a-fA-F]{4}-([0-9a-fA-F]{12})$`)
+
+// fetchDescription fetches and parses the device description at location
+func fetchDescription(ctx context.Context, client *http.Client, location string) (*Device, error) {
+	base, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status fetching %s: %s", location, resp.Status)
+	}
+
+	var desc description
+	if err := xml.NewDecoder(resp.Body).Decode(&desc); err != nil {
+		return nil, fmt.Errorf("failed to parse description at %s: %w", location, err)
+	}
+
+	device := &Device{
+		Location:     location,
+		FriendlyName: desc.Device.FriendlyName,
+		Manufacturer: desc.Device.Manufacturer,
+		ModelName:    desc.Device.ModelName,
+		UDN:          desc.Device.UDN,
+		MACAddress:   normalizeMAC(desc.Device.MACAddress),
+	}
+
+	// Fall back to the MAC address embedded in the UDN
+	if device.MACAddress == "" {
+		if match := udnMACPattern.FindStringSubmatch(device.UDN); match != nil {
+			device.MACAddress = normalizeMAC(match[1])
+		}
+	}
+
+	// The API client wants the scheme and host only, service paths are absolute
+	if desc.Device.ScalarWebAPI.BaseURL != "" {
+		apiURL, err := base.Parse(desc.Device.ScalarWebAPI.BaseURL)
+		if err == nil {
+			device.BaseURL = (&url.URL{Scheme: apiURL.Scheme, Host: apiURL.Host}).String()
+		}
+	}
+
+	for _, service := range desc.Device.Services {
+		if service.ServiceType == IRCCService {
+			irccURL, err := base.Parse(service.ControlURL)
+			if err == nil {
+				device.IRCCURL = irccURL.String()
+			}
+		}
+	}
+
+	// Only the IRCC service answered, assume the API lives on the same host
+	if device.BaseURL == "" && device.IRCCURL != "" {
+		device.BaseURL = (&url.URL{Scheme: base.Scheme, Host: base.Hostname()}).String()
+	}
+
+	if device.BaseURL == "" {
+		return nil, fmt.Errorf("%s does not describe a Bravia TV", location)
+	}
+
+	return device, nil
+}
+
+// normalizeMAC formats a MAC address as colon separated lower-case pairs
+func normalizeMAC(mac string) string {
+	hex := strings.ToLower(strings.NewReplacer(":", "", "-", "", ".", "").Replace(strings.TrimSpace(mac)))
+	if len(hex) != 12 {
+		return ""
+	}
+
+	pairs := make([]string, 0, 6)
+	for i := 0; i < len(hex); i += 2 {
+		pairs = append(pairs, hex[i:i+2])
+	}
+	return strings.Join(pairs, ":")
+}
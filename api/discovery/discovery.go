@@ -0,0 +1,201 @@
+// Package discovery finds Sony Bravia TVs on the local network using SSDP.
+//
+// Discover sends an SSDP M-SEARCH for the Bravia services, then fetches and parses
+// the UPnP device description of every TV that answers:
+//
+//	devices, err := discovery.Discover(ctx)
+//	for _, device := range devices {
+//		fmt.Println(device.FriendlyName, device.BaseURL)
+//	}
+package discovery
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"time"
+)
+
+const (
+	// ScalarWebAPIService is the search target of the JSON-RPC API service
+	ScalarWebAPIService = "urn:schemas-sony-com:service:ScalarWebAPI:1"
+	// IRCCService is the search target of the IRCC remote control service
+	IRCCService = "urn:schemas-sony-com:service:IRCC:1"
+
+	// defaultAddress is the SSDP multicast address
+	defaultAddress = "239.255.255.250:1900"
+	// defaultTimeout is how long to wait for responses when the context has no deadline
+	defaultTimeout = 3 * time.Second
+)
+
+// Device is a Bravia TV found on the network
+type Device struct {
+	Location     string `json:"location"`     // URL of the UPnP device description
+	FriendlyName string `json:"friendlyName"` // Name of the TV, as set by the user
+	Manufacturer string `json:"manufacturer"`
+	ModelName    string `json:"modelName"`
+	UDN          string `json:"udn"`        // Unique device name
+	MACAddress   string `json:"macAddress"` // MAC address, if the TV advertises it
+	BaseURL      string `json:"baseURL"`    // Base URL to use with api.NewClient
+	IRCCURL      string `json:"irccURL"`    // Control URL of the IRCC service
+}
+
+// options holds the settings of a discovery
+type options struct {
+	address    string
+	targets    []string
+	mx         int
+	httpClient *http.Client
+}
+
+// Option configures a discovery
+type Option func(*options)
+
+// WithAddress sends the M-SEARCH to the given address instead of the SSDP multicast group,
+// which is useful to query a single TV or a local responder in tests
+func WithAddress(address string) Option {
+	return func(o *options) {
+		o.address = address
+	}
+}
+
+// WithSearchTargets replaces the search targets sent in the M-SEARCH
+func WithSearchTargets(targets ...string) Option {
+	return func(o *options) {
+		o.targets = targets
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to fetch device descriptions
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
+}
+
+// Discover searches the network for Bravia TVs until the context is done,
+// or for a few seconds if it has no deadline. TVs answer in no particular order,
+// so the devices are sorted by friendly name, then location.
+func Discover(ctx context.Context, opts ...Option) ([]Device, error) {
+	o := &options{
+		address:    defaultAddress,
+		targets:    []string{ScalarWebAPIService, IRCCService},
+		mx:         2,
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultTimeout)
+		defer cancel()
+	}
+
+	locations, err := search(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch the descriptions with a fresh deadline, the search used up the original one
+	fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), defaultTimeout)
+	defer cancel()
+
+	devices := make([]Device, 0, len(locations))
+	for _, location := range locations {
+		device, err := fetchDescription(fetchCtx, o.httpClient, location)
+		if err != nil {
+			// A device that answered but can't be described is not a usable TV
+			continue
+		}
+		devices = append(devices, *device)
+	}
+
+	slices.SortFunc(devices, func(a, b Device) int {
+		return cmp.Or(cmp.Compare(a.FriendlyName, b.FriendlyName), cmp.Compare(a.Location, b.Location))
+	})
+
+	return devices, nil
+}
+
+// search sends the M-SEARCH requests and collects the unique locations of the responses
+func search(ctx context.Context, o *options) ([]string, error) {
+	conn, err := net.ListenPacket("udp4", ":0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open socket: %w", err)
+	}
+	defer conn.Close()
+
+	addr, err := net.ResolveUDPAddr("udp4", o.address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", o.address, err)
+	}
+
+	for _, target := range o.targets {
+		msg := fmt.Sprintf("M-SEARCH * HTTP/1.1\r\nHOST: %s\r\nMAN: \"ssdp:discover\"\r\nMX: %d\r\nST: %s\r\n\r\n", defaultAddress, o.mx, target)
+		if _, err := conn.WriteTo([]byte(msg), addr); err != nil {
+			return nil, fmt.Errorf("failed to send M-SEARCH: %w", err)
+		}
+	}
+
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	// Unblock the read early if the context is canceled
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetReadDeadline(time.Now())
+	})
+	defer stop()
+
+	var locations []string
+	seen := make(map[string]bool)
+	buf := make([]byte, 2048)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, os.ErrDeadlineExceeded) {
+				return locations, nil
+			}
+			return locations, err
+		}
+
+		location, ok := parseResponse(buf[:n], o.targets)
+		if !ok || seen[location] {
+			continue
+		}
+		seen[location] = true
+		locations = append(locations, location)
+	}
+}
+
+// parseResponse returns the location of an SSDP response matching one of the search targets
+func parseResponse(data []byte, targets []string) (string, bool) {
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), nil)
+	if err != nil {
+		return "", false
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", false
+	}
+
+	st := resp.Header.Get("ST")
+	for _, target := range targets {
+		if st == target {
+			location := resp.Header.Get("LOCATION")
+			return location, location != ""
+		}
+	}
+	return "", false
+}
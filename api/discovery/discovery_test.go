@@ -0,0 +1,142 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// descriptionXML is the device description of a TV, with %s replaced by its name
+const descriptionXML = `<?xml version="1.0"?>
+<root xmlns="urn:schemas-upnp-org:device-1-0">
+	<device>
+		<friendlyName>%s</friendlyName>
+		<manufacturer>Sony Corporation</manufacturer>
+		<modelName>KD-55X85J</modelName>
+		<UDN>uuid:00000000-0000-1010-8000-02000000000a</UDN>
+		<av:X_ScalarWebAPI_DeviceInfo xmlns:av="urn:schemas-sony-com:av">
+			<av:X_ScalarWebAPI_BaseURL>http://192.0.2.10/sony</av:X_ScalarWebAPI_BaseURL>
+		</av:X_ScalarWebAPI_DeviceInfo>
+	</device>
+</root>`
+
+// respond starts a UDP responder answering every M-SEARCH with the given responses,
+// and returns its address
+func respond(t *testing.T, responses ...string) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 2048)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if !strings.HasPrefix(string(buf[:n]), "M-SEARCH") {
+				continue
+			}
+			for _, response := range responses {
+				conn.WriteTo([]byte(response), addr)
+			}
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+// ssdpResponse returns an SSDP response for the search target st at location
+func ssdpResponse(st, location string) string {
+	return fmt.Sprintf("HTTP/1.1 200 OK\r\nCACHE-CONTROL: max-age=1800\r\nLOCATION: %s\r\nST: %s\r\n\r\n", location, st)
+}
+
+func TestDiscover(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bedroom.xml":
+			fmt.Fprintf(w, descriptionXML, "Bedroom")
+		case "/living-room.xml":
+			fmt.Fprintf(w, descriptionXML, "Living Room")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	// The living room answers first, and twice as it has both services
+	address := respond(t,
+		ssdpResponse(ScalarWebAPIService, srv.URL+"/living-room.xml"),
+		ssdpResponse(IRCCService, srv.URL+"/living-room.xml"),
+		ssdpResponse("urn:schemas-upnp-org:device:MediaRenderer:1", srv.URL+"/speaker.xml"),
+		ssdpResponse(ScalarWebAPIService, srv.URL+"/missing.xml"),
+		ssdpResponse(ScalarWebAPIService, srv.URL+"/bedroom.xml"),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	devices, err := Discover(ctx, WithAddress(address))
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+
+	if len(devices) != 2 {
+		t.Fatalf("Discover() found %d devices, want 2: %+v", len(devices), devices)
+	}
+	if devices[0].FriendlyName != "Bedroom" || devices[1].FriendlyName != "Living Room" {
+		t.Errorf("Discover() = %q, %q, want them sorted by name", devices[0].FriendlyName, devices[1].FriendlyName)
+	}
+
+	device := devices[0]
+	if device.BaseURL != "http://192.0.2.10" {
+		t.Errorf("BaseURL = %q, want http://192.0.2.10", device.BaseURL)
+	}
+	if device.MACAddress != "02:00:00:00:00:0a" {
+		t.Errorf("MACAddress = %q, want the address from the UDN", device.MACAddress)
+	}
+}
+
+func TestDiscoverNoResponse(t *testing.T) {
+	address := respond(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	devices, err := Discover(ctx, WithAddress(address))
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	if len(devices) != 0 {
+		t.Errorf("Discover() = %+v, want no devices", devices)
+	}
+}
+
+func TestNormalizeMAC(t *testing.T) {
+	tests := []struct {
+		mac  string
+		want string
+	}{
+		{"AA:BB:CC:DD:EE:FF", "aa:bb:cc:dd:ee:ff"},
+		{"aa-bb-cc-dd-ee-ff", "aa:bb:cc:dd:ee:ff"},
+		{"aabb.ccdd.eeff", "aa:bb:cc:dd:ee:ff"},
+		{" aabbccddeeff ", "aa:bb:cc:dd:ee:ff"},
+		{"aa:bb:cc", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeMAC(tt.mac); got != tt.want {
+			t.Errorf("normalizeMAC(%q) = %q, want %q", tt.mac, got, tt.want)
+		}
+	}
+}
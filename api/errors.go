@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// Error is an error returned by the TV in the error field of a JSON-RPC response.
+// Use errors.Is with the sentinel values below to check for well-known codes.
+type Error struct {
+	Code    int    // Code is the Sony error code
+	Message string // Message is the error message sent by the TV, if any
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("bravia: error code %d", e.Code)
+	}
+	return fmt.Sprintf("bravia: %s (code %d)", e.Message, e.Code)
+}
+
+// Is reports whether target is an *Error with the same code, so that
+// errors.Is(err, api.ErrDisplayOff) matches regardless of the message
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Well-known error codes returned by the TV
+var (
+	ErrIllegalArgument    = &Error{Code: 3, Message: "illegal argument"}
+	ErrIllegalState       = &Error{Code: 7, Message: "illegal state"}
+	ErrNoSuchMethod       = &Error{Code: 12, Message: "no such method"}
+	ErrUnsupportedVersion = &Error{Code: 14, Message: "unsupported version"}
+	ErrUnauthorized       = &Error{Code: 401, Message: "unauthorized"}
+	ErrForbidden          = &Error{Code: 403, Message: "forbidden"}
+	ErrDisplayOff         = &Error{Code: 40005, Message: "display is turned off"}
+	ErrInputUnavailable   = &Error{Code: 41001, Message: "input is unavailable"}
+)
+
+// newError creates an *Error from the raw [code, message] pair of a JSON-RPC response.
+// Values of unexpected types are tolerated rather than trusted.
+func newError(raw [2]interface{}) *Error {
+	e := &Error{}
+
+	// JSON numbers are decoded as float64 into interface{}
+	if code, ok := raw[0].(float64); ok {
+		e.Code = int(code)
+	}
+	if message, ok := raw[1].(string); ok {
+		e.Message = message
+	}
+
+	return e
+}
+
+// UnsupportedError is returned when version negotiation finds that the TV doesn't support
+// a method, or none of the versions of it that the client knows how to call
+type UnsupportedError struct {
+	Path     string   // Path of the service, e.g. "/sony/audio"
+	Method   string   // Name of the method
+	Versions []string // Versions of the method supported by the TV, empty if the method is missing
+}
+
+// Error implements the error interface
+func (e *UnsupportedError) Error() string {
+	if len(e.Versions) == 0 {
+		return fmt.Sprintf("bravia: %s#%s is not supported on this TV", e.Path, e.Method)
+	}
+	return fmt.Sprintf("bravia: %s#%s is only supported in versions %s on this TV", e.Path, e.Method, strings.Join(e.Versions, ", "))
+}
+
+// Is makes errors.Is match ErrNoSuchMethod when the method is missing,
+// and ErrUnsupportedVersion when only other versions of it are available
+func (e *UnsupportedError) Is(target error) bool {
+	if len(e.Versions) == 0 {
+		return target == ErrNoSuchMethod
+	}
+	return target == ErrUnsupportedVersion
+}
+
+// IsUnsupported reports whether err means the TV doesn't support the method that was called,
+// or any version of it the client knows. Unlike errors such as the TV being busy or in standby,
+// the answer won't change next time, so it can be remembered.
+func IsUnsupported(err error) bool {
+	return errors.Is(err, ErrNoSuchMethod) || errors.Is(err, ErrUnsupportedVersion)
+}
+
+// IsUnreachable reports whether err means the TV couldn't be reached at all, as opposed to
+// the TV answering with an error. This happens when its network stack is asleep in eco standby.
+func IsUnreachable(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrCircuitOpen) {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
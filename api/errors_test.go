@@ -0,0 +1,34 @@
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/trugamr/bravia/api"
+)
+
+func TestIsUnsupported(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{api.ErrNoSuchMethod, true},
+		{api.ErrUnsupportedVersion, true},
+		{&api.Error{Code: 12, Message: "No Such Method"}, true},
+		{fmt.Errorf("wrapped: %w", api.ErrUnsupportedVersion), true},
+		{&api.UnsupportedError{Path: "/sony/system", Method: "getVersions"}, true},
+		{&api.UnsupportedError{Path: "/sony/audio", Method: "setAudioVolume", Versions: []string{"1.0"}}, true},
+		{&api.Error{Code: 503}, false},
+		{api.ErrDisplayOff, false},
+		{api.ErrIllegalState, false},
+		{context.DeadlineExceeded, false},
+		{nil, false},
+	}
+
+	for _, tt := range tests {
+		if got := api.IsUnsupported(tt.err); got != tt.want {
+			t.Errorf("IsUnsupported(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
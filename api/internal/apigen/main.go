@@ -0,0 +1,177 @@
+// Command apigen generates the typed JSON-RPC service methods of the api package
+// from the declarative method table in methods.go.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// service describes a Bravia API service and the methods it exposes
+type service struct {
+	Name    string   // Name of the service type without the "Service" suffix, e.g. "System"
+	Path    string   // Name of the constant holding the service path, e.g. "systemPath"
+	Methods []method // Methods of the service
+}
+
+// method describes a single JSON-RPC method
+type method struct {
+	Name     string   // JSON-RPC method name, e.g. "getPowerStatus"
+	Versions []string // Versions the params and result types are valid for, in ascending order
+	Doc      string   // Doc comment of the generated method, without the leading method name
+	Params   []param  // Fields of the params object, if the method takes one
+	Result   string   // Go type of the result array, e.g. "[1]PowerStatus"
+	Path     string   // Name of the constant holding the method path, if not the service's
+}
+
+// param describes a field of the params object of a method
+type param struct {
+	Name  string // JSON field name, e.g. "uri"
+	Type  string // Go type, pointer types are optional and omitted when nil
+	Field string // Go field name, defaults to the capitalized JSON name
+	Arg   string // Go argument name, defaults to the JSON name
+}
+
+// Func returns the name of the generated Go method
+func (m method) Func() string {
+	return capitalize(m.Name)
+}
+
+// PathConst returns the name of the constant holding the path to call the method on
+func (m method) PathConst(service service) string {
+	if m.Path != "" {
+		return m.Path
+	}
+	return service.Path
+}
+
+// VersionArgs returns the versions as a list of Go string literals
+func (m method) VersionArgs() string {
+	quoted := make([]string, 0, len(m.Versions))
+	for _, version := range m.Versions {
+		quoted = append(quoted, strconv.Quote(version))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// ParamsType returns the Go type of the params array
+func (m method) ParamsType() string {
+	if len(m.Params) == 0 {
+		return "[0]struct{}"
+	}
+
+	var b strings.Builder
+	b.WriteString("[1]struct {\n")
+	for _, p := range m.Params {
+		tag := p.Name
+		if strings.HasPrefix(p.Type, "*") {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&b, "\t%s %s `json:%q`\n", p.FieldName(), p.Type, tag)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// ParamsValue returns the Go composite literal elements building the params from the arguments
+func (m method) ParamsValue() string {
+	if len(m.Params) == 0 {
+		return "{}"
+	}
+
+	fields := make([]string, 0, len(m.Params))
+	for _, p := range m.Params {
+		fields = append(fields, fmt.Sprintf("%s: %s", p.FieldName(), p.ArgName()))
+	}
+	return "{{" + strings.Join(fields, ", ") + "}}"
+}
+
+// FieldName returns the Go field name of the param
+func (p param) FieldName() string {
+	if p.Field != "" {
+		return p.Field
+	}
+	return capitalize(p.Name)
+}
+
+// ArgName returns the Go argument name of the param
+func (p param) ArgName() string {
+	if p.Arg != "" {
+		return p.Arg
+	}
+	return p.Name
+}
+
+// capitalize upper-cases the first letter of s
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// comment turns text into a Go line comment, one line at a time
+func comment(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("// "+line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+var tmpl = template.Must(template.New("methods").Funcs(template.FuncMap{"comment": comment}).Parse(`// Code generated by apigen. DO NOT EDIT.
+
+package api
+
+import (
+	"context"
+	"net/http"
+)
+{{range $service := .}}{{range .Methods}}
+// {{.Func}}Result is the response from the {{.Name}} method
+type {{.Func}}Result = Result[{{.Result}}]
+
+type {{.Name}}Params {{.ParamsType}}
+
+{{comment (printf "%s %s" .Func .Doc)}}
+func (s *{{$service.Name}}Service) {{.Func}}(ctx context.Context{{range .Params}}, {{.ArgName}} {{.Type}}{{end}}) (*{{.Func}}Result, *http.Response, error) {
+	version, err := s.client.version(ctx, {{.PathConst $service}}, "{{.Name}}", {{.VersionArgs}})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := {{.Name}}Params{{.ParamsValue}}
+	return Call[{{.Result}}](ctx, s.client, {{.PathConst $service}}, "{{.Name}}", version, params)
+}
+{{end}}{{end}}`))
+
+func main() {
+	output := flag.String("output", "methods_gen.go", "File to write the generated code to")
+	flag.Parse()
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, services); err != nil {
+		fmt.Fprintf(os.Stderr, "Error executing template: %s\n", err)
+		os.Exit(1)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error formatting generated code: %s\n", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %s\n", *output, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,309 @@
+package main
+
+// services is the table of JSON-RPC methods to generate, grouped by service.
+// Add a method here and run go generate ./api to expose it on the client.
+var services = []service{
+	{
+		Name: "System",
+		Path: "systemPath",
+		Methods: []method{
+			{
+				Name:     "setPowerStatus",
+				Versions: []string{"1.0"},
+				Doc:      "turns the TV on or off",
+				Params:   []param{{Name: "status", Type: "bool"}},
+				Result:   "[0]struct{}",
+			},
+			{
+				Name:     "getPowerStatus",
+				Versions: []string{"1.0"},
+				Doc:      "returns the power status of the TV",
+				Result:   "[1]PowerStatus",
+			},
+			{
+				Name:     "getCurrentTime",
+				Versions: []string{"1.0"},
+				Doc:      "returns the current time of the TV",
+				Result:   "[1]string",
+			},
+			{
+				Name:     "getSystemInformation",
+				Versions: []string{"1.0"},
+				Doc:      "returns general information about the TV, such as its model and MAC address",
+				Result:   "[1]SystemInformation",
+			},
+			{
+				Name:     "getRemoteControllerInfo",
+				Versions: []string{"1.0"},
+				Doc:      "returns the remote controller bundled with the TV and the IRCC codes it accepts",
+				Result:   "RemoteControllerInfo",
+			},
+			{
+				Name:     "getNetworkSettings",
+				Versions: []string{"1.0"},
+				Doc:      "returns the settings of a network interface, or of all of them if netif is empty",
+				Params:   []param{{Name: "netif", Type: "string"}},
+				Result:   "[1][]NetworkSettings",
+			},
+			{
+				Name:     "getSystemSupportedFunction",
+				Versions: []string{"1.0"},
+				Doc:      "returns the optional functions supported by the TV, such as Wake-on-LAN",
+				Result:   "[1][]SupportedFunction",
+			},
+			{
+				Name:     "getLEDIndicatorStatus",
+				Versions: []string{"1.0"},
+				Doc:      "returns the mode of the LED indicator and whether it is lit",
+				Result:   "[1]LEDIndicatorStatus",
+			},
+			{
+				Name:     "setLEDIndicatorStatus",
+				Versions: []string{"1.1"},
+				Doc: `sets the mode of the LED indicator
+The optional status ("true" or "false") turns the LED on or off in the given mode.
+Use SetLEDIndicator to validate the mode first.`,
+				Params: []param{
+					{Name: "mode", Type: "LEDIndicatorMode"},
+					{Name: "status", Type: "*string"},
+				},
+				Result: "[0]struct{}",
+			},
+			{
+				Name:     "getPowerSavingMode",
+				Versions: []string{"1.0"},
+				Doc:      "returns the power saving mode of the TV",
+				Result:   "[1]PowerSavingStatus",
+			},
+			{
+				Name:     "setPowerSavingMode",
+				Versions: []string{"1.0"},
+				Doc: `sets the power saving mode of the TV
+Use SetPowerSaving to validate the mode first.`,
+				Params: []param{{Name: "mode", Type: "PowerSavingMode"}},
+				Result: "[0]struct{}",
+			},
+			{
+				Name:     "getWolMode",
+				Versions: []string{"1.0"},
+				Doc:      "returns whether the TV wakes up on Wake-on-LAN magic packets",
+				Result:   "[1]WolMode",
+			},
+			{
+				Name:     "setWolMode",
+				Versions: []string{"1.0"},
+				Doc:      "sets whether the TV wakes up on Wake-on-LAN magic packets",
+				Params:   []param{{Name: "enabled", Type: "bool"}},
+				Result:   "[0]struct{}",
+			},
+			{
+				Name:     "getInterfaceInformation",
+				Versions: []string{"1.0"},
+				Doc:      "returns information about the TV interface",
+				Result:   "[1]InterfaceInformation",
+			},
+			{
+				Name:     "requestReboot",
+				Versions: []string{"1.0"},
+				Doc:      "requests a reboot of the TV",
+				Result:   "[0]struct{}",
+			},
+		},
+	},
+	{
+		Name: "Audio",
+		Path: "audioPath",
+		Methods: []method{
+			{
+				Name:     "setAudioVolume",
+				Versions: []string{"1.0", "1.2"},
+				Doc: `sets the volume of the TV
+The following volume formats are accepted:
+  "N"   - Sets the volume to level N, where N is a numeric string (e.g., "25").
+  "+N"  - Increases the volume by N, where N is a numeric string (e.g., "+14").
+  "-N"  - Decreases the volume by N, where N is a numeric string (e.g., "-10").
+The optional ui ("on" or "off") controls whether the TV shows the volume bar.
+It is only understood by version 1.2, so use it together with version negotiation.`,
+				Params: []param{
+					{Name: "volume", Type: "string"},
+					{Name: "target", Type: "string"},
+					{Name: "ui", Type: "*string", Field: "UI"},
+				},
+				Result: "[1]int",
+			},
+			{
+				Name:     "getVolumeInformation",
+				Versions: []string{"1.0"},
+				Doc:      "returns volume information for all targets",
+				Result:   "[1][]VolumeInfo",
+			},
+			{
+				Name:     "setAudioMute",
+				Versions: []string{"1.0"},
+				Doc:      "sets the audio mute status",
+				Params:   []param{{Name: "status", Type: "bool"}},
+				Result:   "[1]bool",
+			},
+			{
+				Name:     "getSoundSettings",
+				Versions: []string{"1.1"},
+				Doc: `returns the current value and candidates of a sound setting, such as the
+output terminal, or of all of them if target is empty
+Use SetSound to validate a value against the candidates before setting it.`,
+				Params: []param{{Name: "target", Type: "string"}},
+				Result: "[1][]AudioSetting",
+			},
+			{
+				Name:     "setSoundSettings",
+				Versions: []string{"1.1"},
+				Doc:      "sets the value of sound settings",
+				Params:   []param{{Name: "settings", Type: "[]AudioSettingValue"}},
+				Result:   "[0]struct{}",
+			},
+			{
+				Name:     "getSpeakerSettings",
+				Versions: []string{"1.0"},
+				Doc: `returns the current value and candidates of a speaker setting, such as the
+TV position, or of all of them if target is empty
+Use SetSpeaker to validate a value against the candidates before setting it.`,
+				Params: []param{{Name: "target", Type: "string"}},
+				Result: "[1][]AudioSetting",
+			},
+			{
+				Name:     "setSpeakerSettings",
+				Versions: []string{"1.0"},
+				Doc:      "sets the value of speaker settings",
+				Params:   []param{{Name: "settings", Type: "[]AudioSettingValue"}},
+				Result:   "[0]struct{}",
+			},
+		},
+	},
+	{
+		Name: "AVContent",
+		Path: "avContentPath",
+		Methods: []method{
+			{
+				Name:     "getCurrentExternalInputsStatus",
+				Versions: []string{"1.0"},
+				Doc:      "returns the status of all external inputs",
+				Result:   "[1][]ExternalInputStatus",
+			},
+			{
+				Name:     "getPlayingContentInfo",
+				Versions: []string{"1.0"},
+				Doc: `returns the input or channel being played
+The TV answers with ErrIllegalState while an app is in the foreground and ErrDisplayOff
+in standby, use NowPlaying to treat those as nothing being played.`,
+				Result: "[1]PlayingContentInfo",
+			},
+			{
+				Name:     "setPlayContent",
+				Versions: []string{"1.0"},
+				Doc:      "plays the content with the given URI, such as an external input",
+				Params:   []param{{Name: "uri", Type: "string", Field: "URI"}},
+				Result:   "[0]struct{}",
+			},
+			{
+				Name:     "getSchemeList",
+				Versions: []string{"1.0"},
+				Doc:      "returns the list of available content schemes",
+				Result:   "[1][]Scheme",
+			},
+			{
+				Name:     "getSourceList",
+				Versions: []string{"1.0"},
+				Doc:      "returns the list of sources for a given scheme",
+				Params:   []param{{Name: "scheme", Type: "string"}},
+				Result:   "[1][]Source",
+			},
+			{
+				Name:     "getContentCount",
+				Versions: []string{"1.0"},
+				Doc:      "returns the count of content items for a given source",
+				Params: []param{
+					{Name: "source", Type: "string"},
+					{Name: "type", Type: "*string", Arg: "contentType"},
+				},
+				Result: "[1]ContentCount",
+			},
+			{
+				Name:     "getContentList",
+				Versions: []string{"1.0"},
+				Doc:      "returns a list of content items for a given source",
+				Params: []param{
+					{Name: "source", Type: "string"},
+					{Name: "stIdx", Type: "*int", Arg: "startIndex"},
+					{Name: "cnt", Type: "*int", Arg: "count"},
+					{Name: "type", Type: "*string", Arg: "contentType"},
+				},
+				Result: "[1][]ContentItem",
+			},
+		},
+	},
+	{
+		Name: "Video",
+		Path: "videoPath",
+		Methods: []method{
+			{
+				Name:     "getPictureQualitySettings",
+				Versions: []string{"1.0"},
+				Doc: `returns the current value and candidates of a picture quality setting,
+or of all of them if target is empty
+Use SetPictureQuality to validate a value against the candidates before setting it.`,
+				Params: []param{{Name: "target", Type: "string"}},
+				Result: "[1][]PictureQualitySetting",
+			},
+			{
+				Name:     "setPictureQualitySettings",
+				Versions: []string{"1.0"},
+				Doc:      "sets the value of picture quality settings",
+				Params:   []param{{Name: "settings", Type: "[]PictureQualityValue"}},
+				Result:   "[0]struct{}",
+			},
+			{
+				Name:     "getScreenRotation",
+				Versions: []string{"1.0"},
+				Doc:      "returns the rotation of the screen",
+				Result:   "[1]ScreenRotation",
+			},
+			{
+				Name:     "setSceneSetting",
+				Versions: []string{"1.0"},
+				Doc:      `sets the scene setting, such as "auto", "auto24pSync" or "general"`,
+				Params:   []param{{Name: "value", Type: "string"}},
+				Result:   "[0]struct{}",
+				Path:     "videoScreenPath",
+			},
+			{
+				Name:     "setPipSubScreenPosition",
+				Versions: []string{"1.0"},
+				Doc:      `sets the position of the picture in picture sub screen, such as "leftTop" or "rightBottom"`,
+				Params:   []param{{Name: "position", Type: "string"}},
+				Result:   "[0]struct{}",
+				Path:     "videoScreenPath",
+			},
+		},
+	},
+	{
+		Name: "AppControl",
+		Path: "appControlPath",
+		Methods: []method{
+			{
+				Name:     "getApplicationList",
+				Versions: []string{"1.0"},
+				Doc:      "returns the list of applications installed on the TV",
+				Result:   "[1][]Application",
+			},
+			{
+				Name:     "setActiveApp",
+				Versions: []string{"1.0"},
+				Doc:      "launches the application with the given URI",
+				Params: []param{
+					{Name: "uri", Type: "string", Field: "URI"},
+					{Name: "data", Type: "*string"},
+				},
+				Result: "[0]struct{}",
+			},
+		},
+	},
+}
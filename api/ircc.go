@@ -2,8 +2,18 @@ package api
 
 import (
 	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -82,56 +92,282 @@ const (
 	IRCCDemoMode                   IRCCCommand = "AAAAAgAAAJcAAAB8Aw=="
 )
 
+// irccCommands maps the names of the predefined commands, as used by getRemoteControllerInfo, to their codes
+var irccCommands = IRCCCodes{
+	"Home":                           IRCCHome,
+	"Return":                         IRCCReturn,
+	"Num1":                           IRCCNum1,
+	"Num2":                           IRCCNum2,
+	"Num3":                           IRCCNum3,
+	"Num4":                           IRCCNum4,
+	"Num5":                           IRCCNum5,
+	"Num6":                           IRCCNum6,
+	"Num7":                           IRCCNum7,
+	"Num8":                           IRCCNum8,
+	"Num9":                           IRCCNum9,
+	"Num0":                           IRCCNum0,
+	"DOT":                            IRCCDOT,
+	"VolumeUp":                       IRCCVolumeUp,
+	"VolumeDown":                     IRCCVolumeDown,
+	"Mute":                           IRCCMute,
+	"TvPower":                        IRCCTvPower,
+	"EPG":                            IRCCEPG,
+	"Confirm":                        IRCCConfirm,
+	"ChannelUp":                      IRCCChannelUp,
+	"ChannelDown":                    IRCCChannelDown,
+	"Up":                             IRCCUp,
+	"Down":                           IRCCDown,
+	"Left":                           IRCCLeft,
+	"Right":                          IRCCRight,
+	"Display":                        IRCCDisplay,
+	"SubTitle":                       IRCCSubTitle,
+	"Audio":                          IRCCAudio,
+	"MediaAudioTrack":                IRCCMediaAudioTrack,
+	"Jump":                           IRCCJump,
+	"Exit":                           IRCCExit,
+	"Tv":                             IRCCTv,
+	"Input":                          IRCCInput,
+	"TvInput":                        IRCCTvInput,
+	"Red":                            IRCCRed,
+	"Green":                          IRCCGreen,
+	"Yellow":                         IRCCYellow,
+	"Blue":                           IRCCBlue,
+	"Teletext":                       IRCCTeletext,
+	"Stop":                           IRCCStop,
+	"Rewind":                         IRCCRewind,
+	"Forward":                        IRCCForward,
+	"Prev":                           IRCCPrev,
+	"Next":                           IRCCNext,
+	"Play":                           IRCCPlay,
+	"Rec":                            IRCCRec,
+	"Pause":                          IRCCPause,
+	"OneTouchView":                   IRCCOneTouchView,
+	"GooglePlay":                     IRCCGooglePlay,
+	"Netflix":                        IRCCNetflix,
+	"PartnerApp6":                    IRCCPartnerApp6,
+	"PartnerApp5":                    IRCCPartnerApp5,
+	"YouTube":                        IRCCYouTube,
+	"PartnerApp9":                    IRCCPartnerApp9,
+	"PartnerApp7":                    IRCCPartnerApp7,
+	"ActionMenu":                     IRCCActionMenu,
+	"ApplicationLauncher":            IRCCApplicationLauncher,
+	"Help":                           IRCCHelp,
+	"ShopRemoteControlForcedDynamic": IRCCShopRemoteControlForcedDynamic,
+	"WakeUp":                         IRCCWakeUp,
+	"PowerOff":                       IRCCPowerOff,
+	"Sleep":                          IRCCSleep,
+	"Hdmi1":                          IRCCHdmi1,
+	"Hdmi2":                          IRCCHdmi2,
+	"Hdmi3":                          IRCCHdmi3,
+	"DemoMode":                       IRCCDemoMode,
+}
+
+// LookupIRCCCommand returns the predefined command with the given name, e.g. "VolumeUp", ignoring case
+func LookupIRCCCommand(name string) (IRCCCommand, bool) {
+	return irccCommands.Lookup(name)
+}
+
+// IRCCCommandNames returns the names of the predefined commands in alphabetical order
+func IRCCCommandNames() []string {
+	return irccCommands.Names()
+}
+
+// ErrUnknownCommand is returned when resolving a name the TV has no IRCC code for
+var ErrUnknownCommand = errors.New("bravia: unknown IRCC command")
+
+// IRCCCodes maps command names to the IRCC codes accepted by a TV
+type IRCCCodes map[string]IRCCCommand
+
+// Lookup returns the code of the command with the given name, ignoring case
+func (c IRCCCodes) Lookup(name string) (IRCCCommand, bool) {
+	if command, ok := c[name]; ok {
+		return command, true
+	}
+	for n, command := range c {
+		if strings.EqualFold(n, name) {
+			return command, true
+		}
+	}
+	return "", false
+}
+
+// Names returns the command names in alphabetical order
+func (c IRCCCodes) Names() []string {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// irccCodeCache caches the IRCC codes once they have been fetched from the TV
+type irccCodeCache struct {
+	mu sync.Mutex
+	// codes holds the codes reported by the TV, or the predefined ones if it can't report them
+	codes IRCCCodes
+}
+
 // IRCCService handles IRCC (infrared compatible control) commands
 type IRCCService service
 
+// Codes returns the IRCC codes accepted by the TV, as reported by getRemoteControllerInfo.
+// The predefined codes are returned for TVs that can't report them. Results are cached by the client.
+func (s *IRCCService) Codes(ctx context.Context) (IRCCCodes, error) {
+	cache := s.client.irccCodes
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.codes != nil {
+		return cache.codes, nil
+	}
+
+	result, _, err := s.client.System.GetRemoteControllerInfo(ctx)
+	if IsUnsupported(err) {
+		// The TV doesn't know the method, remember to use the predefined codes instead
+		cache.codes = irccCommands
+		return cache.codes, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return nil, errors.New("bravia: getRemoteControllerInfo returned no result")
+	}
+
+	codes := make(IRCCCodes, len(result.Result.Commands))
+	for _, command := range result.Result.Commands {
+		codes[command.Name] = IRCCCommand(command.Value)
+	}
+	cache.codes = codes
+
+	return codes, nil
+}
+
+// Resolve returns the IRCC code for a command name supported by the TV, ignoring case.
+// Raw base64 codes are returned as is.
+func (s *IRCCService) Resolve(ctx context.Context, name string) (IRCCCommand, error) {
+	codes, err := s.Codes(ctx)
+	if err != nil {
+		return "", err
+	}
+	if command, ok := codes.Lookup(name); ok {
+		return command, nil
+	}
+
+	// IRCC codes are base64 encoded and at least 8 bytes long, which rules out short names
+	if code, err := base64.StdEncoding.DecodeString(name); err == nil && len(code) >= 8 {
+		return IRCCCommand(name), nil
+	}
+
+	return "", fmt.Errorf("%w %q", ErrUnknownCommand, name)
+}
+
+// IRCCError represents a SOAP fault returned by the IRCC endpoint, such as for an invalid code
+type IRCCError struct {
+	StatusCode  int    // HTTP status code of the response
+	Code        int    // UPnP error code, e.g. 800
+	Description string // UPnP error description
+}
+
+func (e *IRCCError) Error() string {
+	if e.Description == "" {
+		return fmt.Sprintf("bravia: IRCC error %d", e.Code)
+	}
+	return fmt.Sprintf("bravia: IRCC error %d: %s", e.Code, e.Description)
+}
+
 // SendIRCCCommand sends an IRCC command to control the TV remotely
-// The command parameter can be either a predefined IRCCCommand constant or a custom IRCC code string
-func (s *IRCCService) SendIRCCCommand(command string) (*http.Response, error) {
-	body := s.buildIRCCXML(command)
+// The command parameter can be either a predefined IRCCCommand constant or a custom IRCC code string.
+// A SOAP fault sent by the TV is returned as *IRCCError.
+func (s *IRCCService) SendIRCCCommand(ctx context.Context, command string) (*http.Response, error) {
+	body, err := buildIRCCXML(command)
+	if err != nil {
+		return nil, err
+	}
 
 	req, err := s.client.NewRequest(http.MethodPost, irccPath, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	// Override the body with XML
-	req.Body = http.NoBody
-	req.GetBody = nil
+	// Override the body with XML, keeping it replayable for retries
 	req.ContentLength = int64(len(body))
-	req.Body = &readCloser{bytes.NewReader(body)}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
 
 	// Set SOAP-specific headers
 	req.Header.Set("Content-Type", "text/xml; charset=UTF-8")
 	req.Header.Set("SOAPACTION", `"urn:schemas-sony-com:service:IRCC:1#X_SendIRCC"`)
 
-	resp, err := s.client.client.Do(req)
+	start := time.Now()
+	resp, err := s.client.sendIRCC(ctx, req)
+	s.client.logCall(ctx, "bravia ircc", start, resp, err, slog.String("code", command))
+
+	return resp, err
+}
+
+// sendIRCC sends an X_SendIRCC request, turning SOAP faults and error statuses into errors
+func (c *Client) sendIRCC(ctx context.Context, req *http.Request) (*http.Response, error) {
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return resp, err
 	}
 	defer resp.Body.Close()
 
-	return resp, nil
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+
+	var envelope irccFaultEnvelope
+	if err := xml.NewDecoder(resp.Body).Decode(&envelope); err == nil && envelope.Body.Fault != nil {
+		fault := envelope.Body.Fault.Detail.UPnPError
+		return resp, &IRCCError{StatusCode: resp.StatusCode, Code: fault.ErrorCode, Description: fault.ErrorDescription}
+	}
+
+	// Not a SOAP fault, report the HTTP status instead
+	return resp, &Error{Code: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
 }
 
-// buildIRCCXML builds the SOAP XML body for an IRCC command
-func (s *IRCCService) buildIRCCXML(code string) []byte {
-	xml := fmt.Sprintf(`<?xml version="1.0"?>
-<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">
-	<s:Body>
-		<u:X_SendIRCC xmlns:u="urn:schemas-sony-com:service:IRCC:1">
-			<IRCCCode>%s</IRCCCode>
-		</u:X_SendIRCC>
-	</s:Body>
-</s:Envelope>`, code)
-	return []byte(xml)
+// irccEnvelope is the SOAP envelope of an X_SendIRCC request
+type irccEnvelope struct {
+	XMLName       xml.Name `xml:"s:Envelope"`
+	Namespace     string   `xml:"xmlns:s,attr"`
+	EncodingStyle string   `xml:"s:encodingStyle,attr"`
+	SendIRCC      struct {
+		Namespace string `xml:"xmlns:u,attr"`
+		IRCCCode  string `xml:"IRCCCode"`
+	} `xml:"s:Body>u:X_SendIRCC"`
 }
 
-// readCloser wraps a bytes.Reader to implement io.ReadCloser
-type readCloser struct {
-	*bytes.Reader
+// irccFaultEnvelope is the SOAP envelope of a response, which holds a fault when the command failed
+type irccFaultEnvelope struct {
+	Body struct {
+		Fault *struct {
+			Detail struct {
+				UPnPError struct {
+					ErrorCode        int    `xml:"errorCode"`
+					ErrorDescription string `xml:"errorDescription"`
+				} `xml:"UPnPError"`
+			} `xml:"detail"`
+		} `xml:"Fault"`
+	} `xml:"Body"`
 }
 
-func (rc *readCloser) Close() error {
-	return nil
+// buildIRCCXML builds the SOAP XML body for an IRCC command
+func buildIRCCXML(code string) ([]byte, error) {
+	envelope := irccEnvelope{
+		Namespace:     "http://schemas.xmlsoap.org/soap/envelope/",
+		EncodingStyle: "http://schemas.xmlsoap.org/soap/encoding/",
+	}
+	envelope.SendIRCC.Namespace = "urn:schemas-sony-com:service:IRCC:1"
+	envelope.SendIRCC.IRCCCode = code
+
+	body, err := xml.Marshal(envelope)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
 }
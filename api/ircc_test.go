@@ -0,0 +1,55 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/trugamr/bravia/api"
+	"github.com/trugamr/bravia/api/bravatest"
+)
+
+// customCommand is an IRCC code only known to the fake TV, not among the predefined codes
+var customCommand = api.RemoteCommand{Name: "CustomKey", Value: "AAAAAgAAABoAAAB8Aw=="}
+
+func TestIRCCCodes(t *testing.T) {
+	srv := bravatest.NewServer(bravatest.WithPSK("secret"), bravatest.WithRemoteCommands(customCommand))
+	defer srv.Close()
+
+	command, err := srv.APIClient().IRCC.Resolve(context.Background(), "customkey")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if command != api.IRCCCommand(customCommand.Value) {
+		t.Errorf("Resolve() = %q, want %q", command, customCommand.Value)
+	}
+}
+
+func TestIRCCCodesPredefined(t *testing.T) {
+	srv := bravatest.NewServer(bravatest.WithPSK("secret"), bravatest.WithRemoteCommands(customCommand))
+	defer srv.Close()
+
+	client := srv.APIClient()
+	srv.TV.SetFault("getRemoteControllerInfo", bravatest.Fault{Err: api.ErrNoSuchMethod, Times: 1})
+
+	codes, err := client.IRCC.Codes(context.Background())
+	if err != nil {
+		t.Fatalf("Codes() error = %v", err)
+	}
+	if command, ok := codes.Lookup("Home"); !ok || command != api.IRCCHome {
+		t.Errorf("Lookup(Home) = %q, %v, want the predefined code", command, ok)
+	}
+
+	// TVs without getRemoteControllerInfo are remembered, the TV isn't asked again
+	codes, err = client.IRCC.Codes(context.Background())
+	if err != nil {
+		t.Fatalf("Codes() error = %v", err)
+	}
+	if _, ok := codes.Lookup(customCommand.Name); ok {
+		t.Error("Codes() asked the TV again after it didn't know the method")
+	}
+
+	if _, err := client.IRCC.Resolve(context.Background(), "nope"); !errors.Is(err, api.ErrUnknownCommand) {
+		t.Errorf("Resolve(nope) error = %v, want %v", err, api.ErrUnknownCommand)
+	}
+}
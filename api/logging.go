@@ -0,0 +1,114 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// LevelTrace is the log level of the full HTTP exchanges, below slog.LevelDebug
+// which is used for a summary of each call
+const LevelTrace = slog.LevelDebug - 4
+
+// ReplaceLevelNames names LevelTrace "TRACE" in logs, instead of "DEBUG-4". Use it as the
+// ReplaceAttr function of slog.HandlerOptions.
+func ReplaceLevelNames(groups []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.LevelKey && a.Value.Any() == LevelTrace {
+		a.Value = slog.StringValue("TRACE")
+	}
+	return a
+}
+
+// redacted replaces the value of headers carrying credentials in logs
+const redacted = "REDACTED"
+
+// sensitiveHeaders lists the headers carrying credentials, which are never logged
+var sensitiveHeaders = []string{headerAuthPSK, "Authorization", "Cookie", "Set-Cookie"}
+
+// logCall logs a summary of a call to the TV at debug level
+func (c *Client) logCall(ctx context.Context, msg string, start time.Time, resp *http.Response, err error, attrs ...slog.Attr) {
+	if c.logger == nil || !c.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs = append(attrs, slog.Duration("latency", time.Since(start)))
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+	if err != nil {
+		var apiErr *Error
+		if errors.As(err, &apiErr) {
+			attrs = append(attrs, slog.Int("code", apiErr.Code))
+		}
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelDebug, msg, attrs...)
+}
+
+// redactHeaders returns a copy of header with the credentials replaced
+func redactHeaders(header http.Header) http.Header {
+	header = header.Clone()
+	for _, name := range sensitiveHeaders {
+		if header.Get(name) != "" {
+			header.Set(name, redacted)
+		}
+	}
+	return header
+}
+
+// traceTransport is an http.RoundTripper that logs full requests and responses at LevelTrace.
+// It sits closest to the network so it sees every attempt with the headers actually sent.
+type traceTransport struct {
+	// transport is the RoundTripper to use for the request
+	transport http.RoundTripper
+	logger    *slog.Logger
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if !t.logger.Enabled(ctx, LevelTrace) {
+		return t.transport.RoundTrip(req)
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+		slog.Any("request_headers", redactHeaders(req.Header)),
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			b, _ := io.ReadAll(body)
+			body.Close()
+			attrs = append(attrs, slog.String("request_body", string(b)))
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	attrs = append(attrs, slog.Duration("latency", time.Since(start)))
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		t.logger.LogAttrs(ctx, LevelTrace, "bravia http", attrs...)
+		return resp, err
+	}
+
+	// Read the body for the log and hand a copy to the caller
+	b, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+
+	attrs = append(attrs,
+		slog.Int("status", resp.StatusCode),
+		slog.Any("response_headers", redactHeaders(resp.Header)),
+		slog.String("response_body", string(b)),
+	)
+	t.logger.LogAttrs(ctx, LevelTrace, "bravia http", attrs...)
+
+	return resp, readErr
+}
@@ -0,0 +1,33 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/trugamr/bravia/api"
+)
+
+func TestReplaceLevelNames(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level:       api.LevelTrace,
+		ReplaceAttr: api.ReplaceLevelNames,
+	}))
+
+	logger.Log(context.Background(), api.LevelTrace, "exchange")
+	logger.Debug("call")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("logged %d lines, want 2:\n%s", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], "level=TRACE") {
+		t.Errorf("trace line = %q, want level=TRACE", lines[0])
+	}
+	if !strings.Contains(lines[1], "level=DEBUG") {
+		t.Errorf("debug line = %q, want level=DEBUG", lines[1])
+	}
+}
@@ -0,0 +1,651 @@
+// Code generated by apigen. DO NOT EDIT.
+
+package api
+
+import (
+	"context"
+	"net/http"
+)
+
+// SetPowerStatusResult is the response from the setPowerStatus method
+type SetPowerStatusResult = Result[[0]struct{}]
+
+type setPowerStatusParams [1]struct {
+	Status bool `json:"status"`
+}
+
+// SetPowerStatus turns the TV on or off
+func (s *SystemService) SetPowerStatus(ctx context.Context, status bool) (*SetPowerStatusResult, *http.Response, error) {
+	version, err := s.client.version(ctx, systemPath, "setPowerStatus", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := setPowerStatusParams{{Status: status}}
+	return Call[[0]struct{}](ctx, s.client, systemPath, "setPowerStatus", version, params)
+}
+
+// GetPowerStatusResult is the response from the getPowerStatus method
+type GetPowerStatusResult = Result[[1]PowerStatus]
+
+type getPowerStatusParams [0]struct{}
+
+// GetPowerStatus returns the power status of the TV
+func (s *SystemService) GetPowerStatus(ctx context.Context) (*GetPowerStatusResult, *http.Response, error) {
+	version, err := s.client.version(ctx, systemPath, "getPowerStatus", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := getPowerStatusParams{}
+	return Call[[1]PowerStatus](ctx, s.client, systemPath, "getPowerStatus", version, params)
+}
+
+// GetCurrentTimeResult is the response from the getCurrentTime method
+type GetCurrentTimeResult = Result[[1]string]
+
+type getCurrentTimeParams [0]struct{}
+
+// GetCurrentTime returns the current time of the TV
+func (s *SystemService) GetCurrentTime(ctx context.Context) (*GetCurrentTimeResult, *http.Response, error) {
+	version, err := s.client.version(ctx, systemPath, "getCurrentTime", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := getCurrentTimeParams{}
+	return Call[[1]string](ctx, s.client, systemPath, "getCurrentTime", version, params)
+}
+
+// GetSystemInformationResult is the response from the getSystemInformation method
+type GetSystemInformationResult = Result[[1]SystemInformation]
+
+type getSystemInformationParams [0]struct{}
+
+// GetSystemInformation returns general information about the TV, such as its model and MAC address
+func (s *SystemService) GetSystemInformation(ctx context.Context) (*GetSystemInformationResult, *http.Response, error) {
+	version, err := s.client.version(ctx, systemPath, "getSystemInformation", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := getSystemInformationParams{}
+	return Call[[1]SystemInformation](ctx, s.client, systemPath, "getSystemInformation", version, params)
+}
+
+// GetRemoteControllerInfoResult is the response from the getRemoteControllerInfo method
+type GetRemoteControllerInfoResult = Result[RemoteControllerInfo]
+
+type getRemoteControllerInfoParams [0]struct{}
+
+// GetRemoteControllerInfo returns the remote controller bundled with the TV and the IRCC codes it accepts
+func (s *SystemService) GetRemoteControllerInfo(ctx context.Context) (*GetRemoteControllerInfoResult, *http.Response, error) {
+	version, err := s.client.version(ctx, systemPath, "getRemoteControllerInfo", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := getRemoteControllerInfoParams{}
+	return Call[RemoteControllerInfo](ctx, s.client, systemPath, "getRemoteControllerInfo", version, params)
+}
+
+// GetNetworkSettingsResult is the response from the getNetworkSettings method
+type GetNetworkSettingsResult = Result[[1][]NetworkSettings]
+
+type getNetworkSettingsParams [1]struct {
+	Netif string `json:"netif"`
+}
+
+// GetNetworkSettings returns the settings of a network interface, or of all of them if netif is empty
+func (s *SystemService) GetNetworkSettings(ctx context.Context, netif string) (*GetNetworkSettingsResult, *http.Response, error) {
+	version, err := s.client.version(ctx, systemPath, "getNetworkSettings", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := getNetworkSettingsParams{{Netif: netif}}
+	return Call[[1][]NetworkSettings](ctx, s.client, systemPath, "getNetworkSettings", version, params)
+}
+
+// GetSystemSupportedFunctionResult is the response from the getSystemSupportedFunction method
+type GetSystemSupportedFunctionResult = Result[[1][]SupportedFunction]
+
+type getSystemSupportedFunctionParams [0]struct{}
+
+// GetSystemSupportedFunction returns the optional functions supported by the TV, such as Wake-on-LAN
+func (s *SystemService) GetSystemSupportedFunction(ctx context.Context) (*GetSystemSupportedFunctionResult, *http.Response, error) {
+	version, err := s.client.version(ctx, systemPath, "getSystemSupportedFunction", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := getSystemSupportedFunctionParams{}
+	return Call[[1][]SupportedFunction](ctx, s.client, systemPath, "getSystemSupportedFunction", version, params)
+}
+
+// GetLEDIndicatorStatusResult is the response from the getLEDIndicatorStatus method
+type GetLEDIndicatorStatusResult = Result[[1]LEDIndicatorStatus]
+
+type getLEDIndicatorStatusParams [0]struct{}
+
+// GetLEDIndicatorStatus returns the mode of the LED indicator and whether it is lit
+func (s *SystemService) GetLEDIndicatorStatus(ctx context.Context) (*GetLEDIndicatorStatusResult, *http.Response, error) {
+	version, err := s.client.version(ctx, systemPath, "getLEDIndicatorStatus", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := getLEDIndicatorStatusParams{}
+	return Call[[1]LEDIndicatorStatus](ctx, s.client, systemPath, "getLEDIndicatorStatus", version, params)
+}
+
+// SetLEDIndicatorStatusResult is the response from the setLEDIndicatorStatus method
+type SetLEDIndicatorStatusResult = Result[[0]struct{}]
+
+type setLEDIndicatorStatusParams [1]struct {
+	Mode   LEDIndicatorMode `json:"mode"`
+	Status *string          `json:"status,omitempty"`
+}
+
+// SetLEDIndicatorStatus sets the mode of the LED indicator
+// The optional status ("true" or "false") turns the LED on or off in the given mode.
+// Use SetLEDIndicator to validate the mode first.
+func (s *SystemService) SetLEDIndicatorStatus(ctx context.Context, mode LEDIndicatorMode, status *string) (*SetLEDIndicatorStatusResult, *http.Response, error) {
+	version, err := s.client.version(ctx, systemPath, "setLEDIndicatorStatus", "1.1")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := setLEDIndicatorStatusParams{{Mode: mode, Status: status}}
+	return Call[[0]struct{}](ctx, s.client, systemPath, "setLEDIndicatorStatus", version, params)
+}
+
+// GetPowerSavingModeResult is the response from the getPowerSavingMode method
+type GetPowerSavingModeResult = Result[[1]PowerSavingStatus]
+
+type getPowerSavingModeParams [0]struct{}
+
+// GetPowerSavingMode returns the power saving mode of the TV
+func (s *SystemService) GetPowerSavingMode(ctx context.Context) (*GetPowerSavingModeResult, *http.Response, error) {
+	version, err := s.client.version(ctx, systemPath, "getPowerSavingMode", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := getPowerSavingModeParams{}
+	return Call[[1]PowerSavingStatus](ctx, s.client, systemPath, "getPowerSavingMode", version, params)
+}
+
+// SetPowerSavingModeResult is the response from the setPowerSavingMode method
+type SetPowerSavingModeResult = Result[[0]struct{}]
+
+type setPowerSavingModeParams [1]struct {
+	Mode PowerSavingMode `json:"mode"`
+}
+
+// SetPowerSavingMode sets the power saving mode of the TV
+// Use SetPowerSaving to validate the mode first.
+func (s *SystemService) SetPowerSavingMode(ctx context.Context, mode PowerSavingMode) (*SetPowerSavingModeResult, *http.Response, error) {
+	version, err := s.client.version(ctx, systemPath, "setPowerSavingMode", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := setPowerSavingModeParams{{Mode: mode}}
+	return Call[[0]struct{}](ctx, s.client, systemPath, "setPowerSavingMode", version, params)
+}
+
+// GetWolModeResult is the response from the getWolMode method
+type GetWolModeResult = Result[[1]WolMode]
+
+type getWolModeParams [0]struct{}
+
+// GetWolMode returns whether the TV wakes up on Wake-on-LAN magic packets
+func (s *SystemService) GetWolMode(ctx context.Context) (*GetWolModeResult, *http.Response, error) {
+	version, err := s.client.version(ctx, systemPath, "getWolMode", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := getWolModeParams{}
+	return Call[[1]WolMode](ctx, s.client, systemPath, "getWolMode", version, params)
+}
+
+// SetWolModeResult is the response from the setWolMode method
+type SetWolModeResult = Result[[0]struct{}]
+
+type setWolModeParams [1]struct {
+	Enabled bool `json:"enabled"`
+}
+
+// SetWolMode sets whether the TV wakes up on Wake-on-LAN magic packets
+func (s *SystemService) SetWolMode(ctx context.Context, enabled bool) (*SetWolModeResult, *http.Response, error) {
+	version, err := s.client.version(ctx, systemPath, "setWolMode", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := setWolModeParams{{Enabled: enabled}}
+	return Call[[0]struct{}](ctx, s.client, systemPath, "setWolMode", version, params)
+}
+
+// GetInterfaceInformationResult is the response from the getInterfaceInformation method
+type GetInterfaceInformationResult = Result[[1]InterfaceInformation]
+
+type getInterfaceInformationParams [0]struct{}
+
+// GetInterfaceInformation returns information about the TV interface
+func (s *SystemService) GetInterfaceInformation(ctx context.Context) (*GetInterfaceInformationResult, *http.Response, error) {
+	version, err := s.client.version(ctx, systemPath, "getInterfaceInformation", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := getInterfaceInformationParams{}
+	return Call[[1]InterfaceInformation](ctx, s.client, systemPath, "getInterfaceInformation", version, params)
+}
+
+// RequestRebootResult is the response from the requestReboot method
+type RequestRebootResult = Result[[0]struct{}]
+
+type requestRebootParams [0]struct{}
+
+// RequestReboot requests a reboot of the TV
+func (s *SystemService) RequestReboot(ctx context.Context) (*RequestRebootResult, *http.Response, error) {
+	version, err := s.client.version(ctx, systemPath, "requestReboot", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := requestRebootParams{}
+	return Call[[0]struct{}](ctx, s.client, systemPath, "requestReboot", version, params)
+}
+
+// SetAudioVolumeResult is the response from the setAudioVolume method
+type SetAudioVolumeResult = Result[[1]int]
+
+type setAudioVolumeParams [1]struct {
+	Volume string  `json:"volume"`
+	Target string  `json:"target"`
+	UI     *string `json:"ui,omitempty"`
+}
+
+// SetAudioVolume sets the volume of the TV
+// The following volume formats are accepted:
+//
+//	"N"   - Sets the volume to level N, where N is a numeric string (e.g., "25").
+//	"+N"  - Increases the volume by N, where N is a numeric string (e.g., "+14").
+//	"-N"  - Decreases the volume by N, where N is a numeric string (e.g., "-10").
+//
+// The optional ui ("on" or "off") controls whether the TV shows the volume bar.
+// It is only understood by version 1.2, so use it together with version negotiation.
+func (s *AudioService) SetAudioVolume(ctx context.Context, volume string, target string, ui *string) (*SetAudioVolumeResult, *http.Response, error) {
+	version, err := s.client.version(ctx, audioPath, "setAudioVolume", "1.0", "1.2")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := setAudioVolumeParams{{Volume: volume, Target: target, UI: ui}}
+	return Call[[1]int](ctx, s.client, audioPath, "setAudioVolume", version, params)
+}
+
+// GetVolumeInformationResult is the response from the getVolumeInformation method
+type GetVolumeInformationResult = Result[[1][]VolumeInfo]
+
+type getVolumeInformationParams [0]struct{}
+
+// GetVolumeInformation returns volume information for all targets
+func (s *AudioService) GetVolumeInformation(ctx context.Context) (*GetVolumeInformationResult, *http.Response, error) {
+	version, err := s.client.version(ctx, audioPath, "getVolumeInformation", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := getVolumeInformationParams{}
+	return Call[[1][]VolumeInfo](ctx, s.client, audioPath, "getVolumeInformation", version, params)
+}
+
+// SetAudioMuteResult is the response from the setAudioMute method
+type SetAudioMuteResult = Result[[1]bool]
+
+type setAudioMuteParams [1]struct {
+	Status bool `json:"status"`
+}
+
+// SetAudioMute sets the audio mute status
+func (s *AudioService) SetAudioMute(ctx context.Context, status bool) (*SetAudioMuteResult, *http.Response, error) {
+	version, err := s.client.version(ctx, audioPath, "setAudioMute", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := setAudioMuteParams{{Status: status}}
+	return Call[[1]bool](ctx, s.client, audioPath, "setAudioMute", version, params)
+}
+
+// GetSoundSettingsResult is the response from the getSoundSettings method
+type GetSoundSettingsResult = Result[[1][]AudioSetting]
+
+type getSoundSettingsParams [1]struct {
+	Target string `json:"target"`
+}
+
+// GetSoundSettings returns the current value and candidates of a sound setting, such as the
+// output terminal, or of all of them if target is empty
+// Use SetSound to validate a value against the candidates before setting it.
+func (s *AudioService) GetSoundSettings(ctx context.Context, target string) (*GetSoundSettingsResult, *http.Response, error) {
+	version, err := s.client.version(ctx, audioPath, "getSoundSettings", "1.1")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := getSoundSettingsParams{{Target: target}}
+	return Call[[1][]AudioSetting](ctx, s.client, audioPath, "getSoundSettings", version, params)
+}
+
+// SetSoundSettingsResult is the response from the setSoundSettings method
+type SetSoundSettingsResult = Result[[0]struct{}]
+
+type setSoundSettingsParams [1]struct {
+	Settings []AudioSettingValue `json:"settings"`
+}
+
+// SetSoundSettings sets the value of sound settings
+func (s *AudioService) SetSoundSettings(ctx context.Context, settings []AudioSettingValue) (*SetSoundSettingsResult, *http.Response, error) {
+	version, err := s.client.version(ctx, audioPath, "setSoundSettings", "1.1")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := setSoundSettingsParams{{Settings: settings}}
+	return Call[[0]struct{}](ctx, s.client, audioPath, "setSoundSettings", version, params)
+}
+
+// GetSpeakerSettingsResult is the response from the getSpeakerSettings method
+type GetSpeakerSettingsResult = Result[[1][]AudioSetting]
+
+type getSpeakerSettingsParams [1]struct {
+	Target string `json:"target"`
+}
+
+// GetSpeakerSettings returns the current value and candidates of a speaker setting, such as the
+// TV position, or of all of them if target is empty
+// Use SetSpeaker to validate a value against the candidates before setting it.
+func (s *AudioService) GetSpeakerSettings(ctx context.Context, target string) (*GetSpeakerSettingsResult, *http.Response, error) {
+	version, err := s.client.version(ctx, audioPath, "getSpeakerSettings", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := getSpeakerSettingsParams{{Target: target}}
+	return Call[[1][]AudioSetting](ctx, s.client, audioPath, "getSpeakerSettings", version, params)
+}
+
+// SetSpeakerSettingsResult is the response from the setSpeakerSettings method
+type SetSpeakerSettingsResult = Result[[0]struct{}]
+
+type setSpeakerSettingsParams [1]struct {
+	Settings []AudioSettingValue `json:"settings"`
+}
+
+// SetSpeakerSettings sets the value of speaker settings
+func (s *AudioService) SetSpeakerSettings(ctx context.Context, settings []AudioSettingValue) (*SetSpeakerSettingsResult, *http.Response, error) {
+	version, err := s.client.version(ctx, audioPath, "setSpeakerSettings", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := setSpeakerSettingsParams{{Settings: settings}}
+	return Call[[0]struct{}](ctx, s.client, audioPath, "setSpeakerSettings", version, params)
+}
+
+// GetCurrentExternalInputsStatusResult is the response from the getCurrentExternalInputsStatus method
+type GetCurrentExternalInputsStatusResult = Result[[1][]ExternalInputStatus]
+
+type getCurrentExternalInputsStatusParams [0]struct{}
+
+// GetCurrentExternalInputsStatus returns the status of all external inputs
+func (s *AVContentService) GetCurrentExternalInputsStatus(ctx context.Context) (*GetCurrentExternalInputsStatusResult, *http.Response, error) {
+	version, err := s.client.version(ctx, avContentPath, "getCurrentExternalInputsStatus", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := getCurrentExternalInputsStatusParams{}
+	return Call[[1][]ExternalInputStatus](ctx, s.client, avContentPath, "getCurrentExternalInputsStatus", version, params)
+}
+
+// GetPlayingContentInfoResult is the response from the getPlayingContentInfo method
+type GetPlayingContentInfoResult = Result[[1]PlayingContentInfo]
+
+type getPlayingContentInfoParams [0]struct{}
+
+// GetPlayingContentInfo returns the input or channel being played
+// The TV answers with ErrIllegalState while an app is in the foreground and ErrDisplayOff
+// in standby, use NowPlaying to treat those as nothing being played.
+func (s *AVContentService) GetPlayingContentInfo(ctx context.Context) (*GetPlayingContentInfoResult, *http.Response, error) {
+	version, err := s.client.version(ctx, avContentPath, "getPlayingContentInfo", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := getPlayingContentInfoParams{}
+	return Call[[1]PlayingContentInfo](ctx, s.client, avContentPath, "getPlayingContentInfo", version, params)
+}
+
+// SetPlayContentResult is the response from the setPlayContent method
+type SetPlayContentResult = Result[[0]struct{}]
+
+type setPlayContentParams [1]struct {
+	URI string `json:"uri"`
+}
+
+// SetPlayContent plays the content with the given URI, such as an external input
+func (s *AVContentService) SetPlayContent(ctx context.Context, uri string) (*SetPlayContentResult, *http.Response, error) {
+	version, err := s.client.version(ctx, avContentPath, "setPlayContent", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := setPlayContentParams{{URI: uri}}
+	return Call[[0]struct{}](ctx, s.client, avContentPath, "setPlayContent", version, params)
+}
+
+// GetSchemeListResult is the response from the getSchemeList method
+type GetSchemeListResult = Result[[1][]Scheme]
+
+type getSchemeListParams [0]struct{}
+
+// GetSchemeList returns the list of available content schemes
+func (s *AVContentService) GetSchemeList(ctx context.Context) (*GetSchemeListResult, *http.Response, error) {
+	version, err := s.client.version(ctx, avContentPath, "getSchemeList", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := getSchemeListParams{}
+	return Call[[1][]Scheme](ctx, s.client, avContentPath, "getSchemeList", version, params)
+}
+
+// GetSourceListResult is the response from the getSourceList method
+type GetSourceListResult = Result[[1][]Source]
+
+type getSourceListParams [1]struct {
+	Scheme string `json:"scheme"`
+}
+
+// GetSourceList returns the list of sources for a given scheme
+func (s *AVContentService) GetSourceList(ctx context.Context, scheme string) (*GetSourceListResult, *http.Response, error) {
+	version, err := s.client.version(ctx, avContentPath, "getSourceList", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := getSourceListParams{{Scheme: scheme}}
+	return Call[[1][]Source](ctx, s.client, avContentPath, "getSourceList", version, params)
+}
+
+// GetContentCountResult is the response from the getContentCount method
+type GetContentCountResult = Result[[1]ContentCount]
+
+type getContentCountParams [1]struct {
+	Source string  `json:"source"`
+	Type   *string `json:"type,omitempty"`
+}
+
+// GetContentCount returns the count of content items for a given source
+func (s *AVContentService) GetContentCount(ctx context.Context, source string, contentType *string) (*GetContentCountResult, *http.Response, error) {
+	version, err := s.client.version(ctx, avContentPath, "getContentCount", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := getContentCountParams{{Source: source, Type: contentType}}
+	return Call[[1]ContentCount](ctx, s.client, avContentPath, "getContentCount", version, params)
+}
+
+// GetContentListResult is the response from the getContentList method
+type GetContentListResult = Result[[1][]ContentItem]
+
+type getContentListParams [1]struct {
+	Source string  `json:"source"`
+	StIdx  *int    `json:"stIdx,omitempty"`
+	Cnt    *int    `json:"cnt,omitempty"`
+	Type   *string `json:"type,omitempty"`
+}
+
+// GetContentList returns a list of content items for a given source
+func (s *AVContentService) GetContentList(ctx context.Context, source string, startIndex *int, count *int, contentType *string) (*GetContentListResult, *http.Response, error) {
+	version, err := s.client.version(ctx, avContentPath, "getContentList", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := getContentListParams{{Source: source, StIdx: startIndex, Cnt: count, Type: contentType}}
+	return Call[[1][]ContentItem](ctx, s.client, avContentPath, "getContentList", version, params)
+}
+
+// GetPictureQualitySettingsResult is the response from the getPictureQualitySettings method
+type GetPictureQualitySettingsResult = Result[[1][]PictureQualitySetting]
+
+type getPictureQualitySettingsParams [1]struct {
+	Target string `json:"target"`
+}
+
+// GetPictureQualitySettings returns the current value and candidates of a picture quality setting,
+// or of all of them if target is empty
+// Use SetPictureQuality to validate a value against the candidates before setting it.
+func (s *VideoService) GetPictureQualitySettings(ctx context.Context, target string) (*GetPictureQualitySettingsResult, *http.Response, error) {
+	version, err := s.client.version(ctx, videoPath, "getPictureQualitySettings", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := getPictureQualitySettingsParams{{Target: target}}
+	return Call[[1][]PictureQualitySetting](ctx, s.client, videoPath, "getPictureQualitySettings", version, params)
+}
+
+// SetPictureQualitySettingsResult is the response from the setPictureQualitySettings method
+type SetPictureQualitySettingsResult = Result[[0]struct{}]
+
+type setPictureQualitySettingsParams [1]struct {
+	Settings []PictureQualityValue `json:"settings"`
+}
+
+// SetPictureQualitySettings sets the value of picture quality settings
+func (s *VideoService) SetPictureQualitySettings(ctx context.Context, settings []PictureQualityValue) (*SetPictureQualitySettingsResult, *http.Response, error) {
+	version, err := s.client.version(ctx, videoPath, "setPictureQualitySettings", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := setPictureQualitySettingsParams{{Settings: settings}}
+	return Call[[0]struct{}](ctx, s.client, videoPath, "setPictureQualitySettings", version, params)
+}
+
+// GetScreenRotationResult is the response from the getScreenRotation method
+type GetScreenRotationResult = Result[[1]ScreenRotation]
+
+type getScreenRotationParams [0]struct{}
+
+// GetScreenRotation returns the rotation of the screen
+func (s *VideoService) GetScreenRotation(ctx context.Context) (*GetScreenRotationResult, *http.Response, error) {
+	version, err := s.client.version(ctx, videoPath, "getScreenRotation", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := getScreenRotationParams{}
+	return Call[[1]ScreenRotation](ctx, s.client, videoPath, "getScreenRotation", version, params)
+}
+
+// SetSceneSettingResult is the response from the setSceneSetting method
+type SetSceneSettingResult = Result[[0]struct{}]
+
+type setSceneSettingParams [1]struct {
+	Value string `json:"value"`
+}
+
+// SetSceneSetting sets the scene setting, such as "auto", "auto24pSync" or "general"
+func (s *VideoService) SetSceneSetting(ctx context.Context, value string) (*SetSceneSettingResult, *http.Response, error) {
+	version, err := s.client.version(ctx, videoScreenPath, "setSceneSetting", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := setSceneSettingParams{{Value: value}}
+	return Call[[0]struct{}](ctx, s.client, videoScreenPath, "setSceneSetting", version, params)
+}
+
+// SetPipSubScreenPositionResult is the response from the setPipSubScreenPosition method
+type SetPipSubScreenPositionResult = Result[[0]struct{}]
+
+type setPipSubScreenPositionParams [1]struct {
+	Position string `json:"position"`
+}
+
+// SetPipSubScreenPosition sets the position of the picture in picture sub screen, such as "leftTop" or "rightBottom"
+func (s *VideoService) SetPipSubScreenPosition(ctx context.Context, position string) (*SetPipSubScreenPositionResult, *http.Response, error) {
+	version, err := s.client.version(ctx, videoScreenPath, "setPipSubScreenPosition", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := setPipSubScreenPositionParams{{Position: position}}
+	return Call[[0]struct{}](ctx, s.client, videoScreenPath, "setPipSubScreenPosition", version, params)
+}
+
+// GetApplicationListResult is the response from the getApplicationList method
+type GetApplicationListResult = Result[[1][]Application]
+
+type getApplicationListParams [0]struct{}
+
+// GetApplicationList returns the list of applications installed on the TV
+func (s *AppControlService) GetApplicationList(ctx context.Context) (*GetApplicationListResult, *http.Response, error) {
+	version, err := s.client.version(ctx, appControlPath, "getApplicationList", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := getApplicationListParams{}
+	return Call[[1][]Application](ctx, s.client, appControlPath, "getApplicationList", version, params)
+}
+
+// SetActiveAppResult is the response from the setActiveApp method
+type SetActiveAppResult = Result[[0]struct{}]
+
+type setActiveAppParams [1]struct {
+	URI  string  `json:"uri"`
+	Data *string `json:"data,omitempty"`
+}
+
+// SetActiveApp launches the application with the given URI
+func (s *AppControlService) SetActiveApp(ctx context.Context, uri string, data *string) (*SetActiveAppResult, *http.Response, error) {
+	version, err := s.client.version(ctx, appControlPath, "setActiveApp", "1.0")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params := setActiveAppParams{{URI: uri, Data: data}}
+	return Call[[0]struct{}](ctx, s.client, appControlPath, "setActiveApp", version, params)
+}
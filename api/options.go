@@ -0,0 +1,75 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Option configures a Client created by NewClient
+type Option func(*Client)
+
+// RequestHook is called with each request before it is sent to the TV
+type RequestHook func(req *http.Request)
+
+// ResponseHook is called with the outcome of each request sent to the TV
+type ResponseHook func(req *http.Request, resp *http.Response, err error)
+
+// WithHTTPClient returns an option using a copy of hc to send requests, keeping its
+// transport, timeout, cookie jar and redirect policy
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		client := *hc
+		c.client = &client
+	}
+}
+
+// WithTimeout returns an option limiting the time each request may take, including retries
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithUserAgent returns an option setting the User-Agent header sent with requests
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithAuthPSK returns an option authenticating with the given pre-shared key
+func WithAuthPSK(psk string) Option {
+	return func(c *Client) {
+		c.psk = psk
+	}
+}
+
+// WithAuthCookie returns an option authenticating with the auth cookie obtained by pairing
+func WithAuthCookie(cookie string) Option {
+	return func(c *Client) {
+		c.authCookie = cookie
+	}
+}
+
+// WithLogger returns an option logging a summary of each call to logger at debug level, and the
+// full HTTP exchanges at LevelTrace. Credentials such as the PSK are redacted.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithRequestHook returns an option calling hook with each request before it is sent
+func WithRequestHook(hook RequestHook) Option {
+	return func(c *Client) {
+		c.requestHooks = append(c.requestHooks, hook)
+	}
+}
+
+// WithResponseHook returns an option calling hook with the outcome of each request
+func WithResponseHook(hook ResponseHook) Option {
+	return func(c *Client) {
+		c.responseHooks = append(c.responseHooks, hook)
+	}
+}
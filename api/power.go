@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/trugamr/bravia/api/wol"
+)
+
+// powerOnTimeout is how long PowerOn waits for the TV to answer before falling back to Wake-on-LAN
+const powerOnTimeout = 3 * time.Second
+
+// ErrNoMACAddress is returned by PowerOn when the TV has to be woken up with Wake-on-LAN,
+// but its MAC address isn't known
+var ErrNoMACAddress = errors.New("bravia: no MAC address known for Wake-on-LAN")
+
+// PowerOnOption configures PowerOn
+type PowerOnOption func(*powerOnConfig)
+
+// powerOnConfig holds the settings of PowerOn
+type powerOnConfig struct {
+	mac      string
+	wol      []wol.Option
+	forceWOL bool
+	learnMAC func(mac string)
+}
+
+// WithWakeOnLAN returns an option sending a Wake-on-LAN packet to mac, configured with opts,
+// when the TV doesn't answer. An empty mac leaves the TV asleep, unless it's learnt first.
+func WithWakeOnLAN(mac string, opts ...wol.Option) PowerOnOption {
+	return func(c *powerOnConfig) {
+		c.mac = mac
+		c.wol = opts
+	}
+}
+
+// WithForceWakeOnLAN returns an option sending the Wake-on-LAN packet without trying the API first
+func WithForceWakeOnLAN() PowerOnOption {
+	return func(c *powerOnConfig) {
+		c.forceWOL = true
+	}
+}
+
+// WithMACLearning returns an option asking the TV for its MAC address while it's reachable,
+// when none was given with WithWakeOnLAN, and calling learn with it so it can be kept for the
+// next time the TV is asleep. Failures to get the address are ignored, it's best effort.
+func WithMACLearning(learn func(mac string)) PowerOnOption {
+	return func(c *powerOnConfig) {
+		c.learnMAC = learn
+	}
+}
+
+// PowerOn turns the TV on through the API. When the TV doesn't answer within a few seconds,
+// because its network stack is asleep in eco standby, it's woken up with a Wake-on-LAN packet
+// instead if configured with WithWakeOnLAN, or else ErrNoMACAddress is returned along with the
+// error from the API. It reports whether the packet was sent, the TV then takes a few seconds
+// to wake up.
+func (s *SystemService) PowerOn(ctx context.Context, opts ...PowerOnOption) (bool, error) {
+	var config powerOnConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	if !config.forceWOL {
+		timeout, cancel := context.WithTimeout(ctx, powerOnTimeout)
+		_, _, err := s.SetPowerStatus(timeout, true)
+		cancel()
+		if err == nil {
+			if config.learnMAC != nil && config.mac == "" {
+				if mac, err := s.MACAddress(ctx); err == nil {
+					config.learnMAC(mac)
+				}
+			}
+			return false, nil
+		}
+		if !IsUnreachable(err) || ctx.Err() != nil {
+			return false, err
+		}
+		if config.mac == "" {
+			return false, fmt.Errorf("%w: %w", ErrNoMACAddress, err)
+		}
+	}
+
+	if config.mac == "" {
+		return false, ErrNoMACAddress
+	}
+	if err := wol.Send(ctx, config.mac, config.wol...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
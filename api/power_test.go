@@ -0,0 +1,118 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/trugamr/bravia/api"
+	"github.com/trugamr/bravia/api/bravatest"
+	"github.com/trugamr/bravia/api/wol"
+)
+
+// listenWOL returns the options sending Wake-on-LAN packets to a local listener, and a channel
+// receiving the packets it gets
+func listenWOL(t *testing.T) ([]wol.Option, <-chan []byte) {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	packets := make(chan []byte, 1)
+	go func() {
+		buf := make([]byte, 1024)
+		n, _, err := conn.ReadFrom(buf)
+		if err == nil {
+			packets <- buf[:n]
+		}
+	}()
+
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+	return []wol.Option{wol.WithBroadcast("127.0.0.1"), wol.WithPort(port), wol.WithRepeat(1)}, packets
+}
+
+func TestPowerOn(t *testing.T) {
+	srv := bravatest.NewServer(bravatest.WithPSK("secret"), bravatest.WithPower(false))
+	defer srv.Close()
+
+	var learnt string
+	woke, err := srv.APIClient().System.PowerOn(context.Background(),
+		api.WithWakeOnLAN(""),
+		api.WithMACLearning(func(mac string) { learnt = mac }),
+	)
+	if err != nil {
+		t.Fatalf("PowerOn() error = %v", err)
+	}
+	if woke {
+		t.Error("PowerOn() used Wake-on-LAN, want the API")
+	}
+	if !srv.TV.Power() {
+		t.Error("Power() = false after PowerOn()")
+	}
+	if learnt != "02:00:00:00:00:01" {
+		t.Errorf("learnt MAC address = %q, want the address of the TV", learnt)
+	}
+}
+
+func TestPowerOnUnreachable(t *testing.T) {
+	// A TV whose network stack is asleep refuses connections
+	srv := bravatest.NewServer(bravatest.WithPSK("secret"))
+	baseURL, _ := url.Parse(srv.URL)
+	srv.Close()
+
+	client := api.NewClient(baseURL, api.WithAuthPSK("secret"))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := client.System.PowerOn(ctx)
+	if !errors.Is(err, api.ErrNoMACAddress) || !api.IsUnreachable(err) {
+		t.Errorf("PowerOn() error = %v, want %v for an unreachable TV", err, api.ErrNoMACAddress)
+	}
+
+	opts, packets := listenWOL(t)
+	woke, err := client.System.PowerOn(ctx, api.WithWakeOnLAN("02:00:00:00:00:01", opts...))
+	if err != nil {
+		t.Fatalf("PowerOn() error = %v", err)
+	}
+	if !woke {
+		t.Error("PowerOn() didn't use Wake-on-LAN")
+	}
+
+	want, _ := wol.MagicPacket("02:00:00:00:00:01")
+	select {
+	case packet := <-packets:
+		if string(packet) != string(want) {
+			t.Errorf("received packet %x, want %x", packet, want)
+		}
+	case <-ctx.Done():
+		t.Fatal("no Wake-on-LAN packet received")
+	}
+}
+
+func TestPowerOnForceWakeOnLAN(t *testing.T) {
+	srv := bravatest.NewServer(bravatest.WithPSK("secret"), bravatest.WithPower(false))
+	defer srv.Close()
+
+	client := srv.APIClient()
+	if _, err := client.System.PowerOn(context.Background(), api.WithForceWakeOnLAN()); !errors.Is(err, api.ErrNoMACAddress) {
+		t.Errorf("PowerOn() error = %v, want %v", err, api.ErrNoMACAddress)
+	}
+
+	opts, packets := listenWOL(t)
+	woke, err := client.System.PowerOn(context.Background(), api.WithWakeOnLAN("02:00:00:00:00:01", opts...), api.WithForceWakeOnLAN())
+	if err != nil || !woke {
+		t.Fatalf("PowerOn() = %v, %v, want a Wake-on-LAN packet", woke, err)
+	}
+	<-packets
+
+	// The API isn't tried at all
+	if srv.TV.Power() {
+		t.Error("Power() = true, want the TV left to the Wake-on-LAN packet")
+	}
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// minRampInterval is the shortest time between two volume steps, so the TV isn't flooded
+// with requests. Faster fades take bigger steps instead.
+const minRampInterval = 100 * time.Millisecond
+
+// RampOption configures RampVolume
+type RampOption func(*rampConfig)
+
+// rampConfig holds the settings of a volume ramp
+type rampConfig struct {
+	progress func(volume int)
+}
+
+// WithRampProgress returns an option calling progress with the volume after each step
+func WithRampProgress(progress func(volume int)) RampOption {
+	return func(c *rampConfig) {
+		c.progress = progress
+	}
+}
+
+// RampVolume gradually changes the volume of target to the level to, spreading the change
+// over duration instead of jumping at once. It steps the volume with relative changes, so
+// it keeps going if someone else adjusts the volume meanwhile. The level is clamped between
+// the minimum and maximum volume of target. Cancelling ctx stops the ramp at the current level
+// and returns the context error.
+func (s *AudioService) RampVolume(ctx context.Context, target string, to int, duration time.Duration, opts ...RampOption) error {
+	var config rampConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	result, _, err := s.GetVolumeInformation(ctx)
+	if err != nil {
+		return err
+	}
+	if result.Result == nil || len(*result.Result) == 0 {
+		return errors.New("bravia: getVolumeInformation returned no result")
+	}
+
+	var info *VolumeInfo
+	volumes := (*result.Result)[0]
+	for i := range volumes {
+		if volumes[i].Target == target {
+			info = &volumes[i]
+			break
+		}
+	}
+	if info == nil {
+		return fmt.Errorf("bravia: volume target %q is not supported by this TV: %w", target, ErrIllegalArgument)
+	}
+
+	volume := info.Volume
+	to = min(max(to, info.MinVolume), info.MaxVolume)
+	steps := abs(to - volume)
+	if steps == 0 {
+		return nil
+	}
+
+	// Take bigger steps when there isn't enough time for one request per level
+	size := 1
+	if duration > 0 && duration/time.Duration(steps) < minRampInterval {
+		size = int((time.Duration(steps)*minRampInterval + duration - 1) / duration)
+	} else if duration <= 0 {
+		size = steps
+	}
+	interval := duration * time.Duration(size) / time.Duration(steps)
+
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	for volume != to {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		delta := min(size, abs(to-volume))
+		if to < volume {
+			delta = -delta
+		}
+		if _, _, err := s.SetAudioVolume(ctx, fmt.Sprintf("%+d", delta), target, nil); err != nil {
+			return err
+		}
+		volume += delta
+
+		if config.progress != nil {
+			config.progress(volume)
+		}
+		timer.Reset(interval)
+	}
+
+	return nil
+}
+
+// abs returns the absolute value of n
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
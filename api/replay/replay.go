@@ -0,0 +1,250 @@
+// Package replay records the HTTP exchanges with a TV to a cassette file and replays them,
+// so the responses of a given model can be shared and used offline or in regression tests.
+//
+//	recorder, err := replay.NewRecorder("tv.json", http.DefaultTransport)
+//	client := api.NewClient(baseURL, api.WithHTTPClient(&http.Client{Transport: recorder}))
+//
+//	player, err := replay.Load("tv.json")
+//	client := api.NewClient(baseURL, api.WithHTTPClient(&http.Client{Transport: player}))
+//
+// Credentials such as the pre-shared key and cookies are scrubbed from the cassette.
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// scrubbed replaces the value of headers carrying credentials in cassettes
+const scrubbed = "REDACTED"
+
+// sensitiveHeaders lists the headers carrying credentials, which are never recorded
+var sensitiveHeaders = []string{"X-Auth-PSK", "Authorization", "Cookie", "Set-Cookie"}
+
+// Cassette holds recorded exchanges, in the order they happened
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and the response the TV sent to it
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request
+type Request struct {
+	Method  string      `json:"method"`
+	Path    string      `json:"path"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records exchanges to a cassette file. The file is
+// written after every exchange, so nothing is lost if the program exits abruptly.
+type Recorder struct {
+	// transport is the RoundTripper to use for the request
+	transport http.RoundTripper
+	path      string
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder returns a Recorder sending requests through transport and recording them to the
+// cassette file at path, which is truncated
+func NewRecorder(path string, transport http.RoundTripper) (*Recorder, error) {
+	r := &Recorder{transport: transport, path: path}
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: Request{
+			Method:  req.Method,
+			Path:    req.URL.RequestURI(),
+			Headers: scrub(req.Header),
+			Body:    string(reqBody),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Headers:    scrub(resp.Header),
+			Body:       string(respBody),
+		},
+	})
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// save writes the cassette to its file
+func (r *Recorder) save() error {
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(r.path, data, 0o600); err != nil {
+		return fmt.Errorf("replay: failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// Player is an http.RoundTripper that answers requests from a cassette without contacting the TV.
+// A request is matched with the first unused interaction having the same method, path and body,
+// ignoring JSON-RPC request IDs, so identical requests get the responses in recorded order.
+// Once all of them were used, the last one is repeated, so polling keeps working.
+type Player struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// Load returns a Player replaying the cassette file at path
+func Load(path string) (*Player, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("replay: failed to read cassette: %w", err)
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("replay: invalid cassette %s: %w", path, err)
+	}
+
+	return NewPlayer(cassette), nil
+}
+
+// NewPlayer returns a Player replaying cassette
+func NewPlayer(cassette Cassette) *Player {
+	return &Player{
+		interactions: cassette.Interactions,
+		used:         make([]bool, len(cassette.Interactions)),
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (p *Player) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	key := normalizeBody(body)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	last := -1
+	for i, interaction := range p.interactions {
+		if interaction.Request.Method != req.Method || interaction.Request.Path != req.URL.RequestURI() {
+			continue
+		}
+		if normalizeBody([]byte(interaction.Request.Body)) != key {
+			continue
+		}
+		last = i
+		if !p.used[i] {
+			p.used[i] = true
+			return newResponse(req, interaction.Response), nil
+		}
+	}
+	if last >= 0 {
+		return newResponse(req, p.interactions[last].Response), nil
+	}
+
+	return nil, fmt.Errorf("replay: no recorded response for %s %s %s", req.Method, req.URL.RequestURI(), bytes.TrimSpace(body))
+}
+
+// newResponse returns the recorded response as a response to req
+func newResponse(req *http.Request, recorded Response) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Headers.Clone(),
+		Body:          io.NopCloser(bytes.NewBufferString(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+}
+
+// readRequestBody returns the body of req, leaving it readable
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// normalizeBody returns the body to match requests on: JSON objects have their "id" removed, since
+// JSON-RPC request IDs differ between runs, and are re-encoded with sorted keys
+func normalizeBody(body []byte) string {
+	var object map[string]interface{}
+	if err := json.Unmarshal(body, &object); err != nil {
+		return string(bytes.TrimSpace(body))
+	}
+	delete(object, "id")
+
+	normalized, err := json.Marshal(object)
+	if err != nil {
+		return string(body)
+	}
+	return string(normalized)
+}
+
+// scrub returns a copy of header with the credentials replaced
+func scrub(header http.Header) http.Header {
+	header = header.Clone()
+	for _, name := range sensitiveHeaders {
+		if header.Get(name) != "" {
+			header.Set(name, scrubbed)
+		}
+	}
+	return header
+}
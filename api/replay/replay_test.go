@@ -0,0 +1,122 @@
+package replay_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/trugamr/bravia/api"
+	"github.com/trugamr/bravia/api/bravatest"
+	"github.com/trugamr/bravia/api/replay"
+)
+
+// offlineClient returns a client answered by the cassette at path, its base URL is never dialed
+func offlineClient(t *testing.T, path string) *api.Client {
+	t.Helper()
+
+	player, err := replay.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	baseURL, _ := url.Parse("http://192.0.2.1")
+	return api.NewClient(baseURL, api.WithAuthPSK("secret"), api.WithHTTPClient(&http.Client{Transport: player}))
+}
+
+// speakerVolume returns the speaker volume from a getVolumeInformation result
+func speakerVolume(t *testing.T, client *api.Client) int {
+	t.Helper()
+
+	result, _, err := client.Audio.GetVolumeInformation(context.Background())
+	if err != nil {
+		t.Fatalf("GetVolumeInformation() error = %v", err)
+	}
+	for _, info := range (*result.Result)[0] {
+		if info.Target == "speaker" {
+			return info.Volume
+		}
+	}
+	t.Fatal("GetVolumeInformation() has no speaker volume")
+	return 0
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	srv := bravatest.NewServer(bravatest.WithPSK("secret"))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "tv.json")
+	recorder, err := replay.NewRecorder(path, http.DefaultTransport)
+	if err != nil {
+		t.Fatalf("NewRecorder() error = %v", err)
+	}
+
+	ctx := context.Background()
+	client := srv.APIClient(api.WithHTTPClient(&http.Client{Transport: recorder}))
+	speakerVolume(t, client)
+	if _, _, err := client.Audio.SetAudioVolume(ctx, "30", "speaker", nil); err != nil {
+		t.Fatalf("SetAudioVolume() error = %v", err)
+	}
+	speakerVolume(t, client)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Error("cassette contains the pre-shared key")
+	}
+
+	// Identical requests get the responses in recorded order, then the last one again
+	client = offlineClient(t, path)
+	if got := speakerVolume(t, client); got != 20 {
+		t.Errorf("first volume = %d, want 20", got)
+	}
+	if _, _, err := client.Audio.SetAudioVolume(ctx, "30", "speaker", nil); err != nil {
+		t.Fatalf("SetAudioVolume() error = %v", err)
+	}
+	for range 2 {
+		if got := speakerVolume(t, client); got != 30 {
+			t.Errorf("volume after setting it = %d, want 30", got)
+		}
+	}
+
+	// Requests that weren't recorded fail instead of reaching the TV
+	if _, _, err := client.Audio.SetAudioVolume(ctx, "40", "speaker", nil); err == nil {
+		t.Error("SetAudioVolume(40) error = nil, want no recorded response")
+	}
+}
+
+// TestCassette replays a cassette recorded from the fake TV, cassettes recorded from real
+// models can be added to testdata the same way to guard against regressions
+func TestCassette(t *testing.T) {
+	client := offlineClient(t, filepath.Join("testdata", "fake-tv.json"))
+	ctx := context.Background()
+
+	info, _, err := client.System.GetSystemInformation(ctx)
+	if err != nil {
+		t.Fatalf("GetSystemInformation() error = %v", err)
+	}
+	if got := (*info.Result)[0].Model; got != "FAKE-BRAVIA" {
+		t.Errorf("Model = %q, want FAKE-BRAVIA", got)
+	}
+
+	status, _, err := client.System.GetPowerStatus(ctx)
+	if err != nil {
+		t.Fatalf("GetPowerStatus() error = %v", err)
+	}
+	if got := (*status.Result)[0].Status; got != "active" {
+		t.Errorf("Status = %q, want active", got)
+	}
+
+	// SOAP faults are replayed like any other response
+	_, err = client.IRCC.SendIRCCCommand(ctx, "AAAAAQAAAAEAAAD/Aw==")
+	var irccErr *api.IRCCError
+	if !errors.As(err, &irccErr) || irccErr.Code != 800 {
+		t.Errorf("SendIRCCCommand() error = %v, want IRCC error 800", err)
+	}
+}
@@ -0,0 +1,74 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/trugamr/bravia/api"
+)
+
+// TestMissingResult checks that helpers report responses without a result as errors, instead
+// of panicking on them
+func TestMissingResult(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer srv.Close()
+
+	baseURL, _ := url.Parse(srv.URL)
+	client := api.NewClient(baseURL)
+
+	tests := []struct {
+		name string
+		call func(ctx context.Context) error
+	}{
+		{"IsScreenOff", func(ctx context.Context) error {
+			_, err := client.System.IsScreenOff(ctx)
+			return err
+		}},
+		{"SetPictureQuality", func(ctx context.Context) error {
+			_, err := client.Video.SetPictureQuality(ctx, api.PictureBrightness, "10")
+			return err
+		}},
+		{"SetSound", func(ctx context.Context) error {
+			_, err := client.Audio.SetSound(ctx, api.SoundOutputTerminal, api.OutputTerminalHDMI)
+			return err
+		}},
+		{"SetSpeaker", func(ctx context.Context) error {
+			_, err := client.Audio.SetSpeaker(ctx, api.SpeakerSubwooferLevel, "10")
+			return err
+		}},
+		{"SetVolume", func(ctx context.Context) error {
+			return client.Audio.SetVolume(ctx, "speaker", "10")
+		}},
+		{"RampVolume", func(ctx context.Context) error {
+			return client.Audio.RampVolume(ctx, "speaker", 10, 0)
+		}},
+		{"NowPlaying", func(ctx context.Context) error {
+			_, err := client.AVContent.NowPlaying(ctx)
+			return err
+		}},
+		{"ContentItems", func(ctx context.Context) error {
+			for _, err := range client.AVContent.ContentItems(ctx, "tv:dvbt") {
+				return err
+			}
+			return nil
+		}},
+		{"MACAddress", func(ctx context.Context) error {
+			_, err := client.System.MACAddress(ctx)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(context.Background()); err == nil {
+				t.Errorf("%s() error = nil, want an error for the missing result", tt.name)
+			}
+		})
+	}
+}
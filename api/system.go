@@ -1,8 +1,11 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
-	"net/http"
+	"fmt"
+	"strings"
 )
 
 const (
@@ -12,218 +15,293 @@ const (
 // SystemService handles requests related to system, such as power status
 type SystemService service
 
-// SetPowerStatusResult is the response from the setPowerStatus method
-type SetPowerStatusResult = Result[[0]struct{}]
+// PowerStatus represents the power status of the TV
+type PowerStatus struct {
+	Status string `json:"status"`
+}
 
-type setPowerStatusParams [1]struct {
-	Status bool `json:"status"`
+// SystemInformation represents general information about the TV
+type SystemInformation struct {
+	Product    string `json:"product"`
+	Region     string `json:"region"`
+	Language   string `json:"language"`
+	Model      string `json:"model"`
+	Serial     string `json:"serial"`
+	MACAddr    string `json:"macAddr"`
+	Name       string `json:"name"`
+	Generation string `json:"generation"`
+	Area       string `json:"area"`
+	CID        string `json:"cid"`
 }
-type setPowerStatusPayload Payload[setPowerStatusParams]
 
-func (s *SystemService) SetPowerStatus(status bool) (*SetPowerStatusResult, *http.Response, error) {
-	body := setPowerStatusPayload{
-		Method:  "setPowerStatus",
-		ID:      1,
-		Params:  setPowerStatusParams{{Status: status}},
-		Version: "1.0",
-	}
+// NetworkSettings represents the settings of a network interface of the TV
+type NetworkSettings struct {
+	Netif    string   `json:"netif"`    // Interface name, e.g. "eth0" or "wlan0"
+	HWAddr   string   `json:"hwAddr"`   // MAC address of the interface
+	IPAddrV4 string   `json:"ipAddrV4"` // IPv4 address
+	IPAddrV6 string   `json:"ipAddrV6"` // IPv6 address
+	Netmask  string   `json:"netmask"`  // IPv4 netmask
+	Gateway  string   `json:"gateway"`  // IPv4 gateway
+	DNS      []string `json:"dns"`      // DNS servers
+}
 
-	req, err := s.client.NewRequest(http.MethodPost, systemPath, body)
-	if err != nil {
-		return nil, nil, err
-	}
+// SupportedFunction represents an optional function of the TV and whether it is supported
+type SupportedFunction struct {
+	Option string `json:"option"` // Function name, e.g. "WOL"
+	Value  string `json:"value"`  // Support status, e.g. "yes" or a MAC address for WOL
+}
 
-	result := new(SetPowerStatusResult)
-	resp, err := s.client.Do(req, result)
-	if err != nil {
-		return nil, resp, err
-	}
+// PowerSavingMode is the power saving mode of the TV, which dims or blanks the screen
+type PowerSavingMode string
 
-	if result.HasError() {
-		return result, resp, errors.New(result.ErrorMessage())
-	}
+// Power saving modes, not every model supports all of them
+const (
+	PowerSavingOff        PowerSavingMode = "off"
+	PowerSavingLow        PowerSavingMode = "low"
+	PowerSavingHigh       PowerSavingMode = "high"
+	PowerSavingPictureOff PowerSavingMode = "pictureOff" // Turns the picture off, keeping the sound
+)
+
+// PowerSavingModes lists the power saving modes known to the API
+var PowerSavingModes = []PowerSavingMode{PowerSavingOff, PowerSavingLow, PowerSavingHigh, PowerSavingPictureOff}
 
-	return result, resp, nil
+// PowerSavingStatus represents the power saving mode of the TV
+type PowerSavingStatus struct {
+	Mode PowerSavingMode `json:"mode"`
 }
 
-// GetPowerStatusResult is the response from the getPowerStatus method
-type GetPowerStatusResult = Result[[1]struct {
-	Status string `json:"status"`
-}]
+// PictureOff reports whether the screen is blanked while the TV keeps playing
+func (p PowerSavingStatus) PictureOff() bool {
+	return p.Mode == PowerSavingPictureOff
+}
 
-type getPowerStatusParams [0]struct{}
-type getPowerStatusPayload Payload[getPowerStatusParams]
+// LEDIndicatorMode is the mode of the LED indicator on the front of the TV
+type LEDIndicatorMode string
 
-// GetPowerStatus returns the power status of the TV
-func (s *SystemService) GetPowerStatus() (*GetPowerStatusResult, *http.Response, error) {
-	body := getPowerStatusPayload{
-		Method:  "getPowerStatus",
-		ID:      1,
-		Params:  getPowerStatusParams{},
-		Version: "1.0",
-	}
+// LED indicator modes, not every model supports all of them
+const (
+	LEDIndicatorDemo                 LEDIndicatorMode = "Demo"
+	LEDIndicatorAutoBrightnessAdjust LEDIndicatorMode = "AutoBrightnessAdjust"
+	LEDIndicatorDark                 LEDIndicatorMode = "Dark"
+	LEDIndicatorSimpleResponse       LEDIndicatorMode = "SimpleResponse"
+	LEDIndicatorOff                  LEDIndicatorMode = "Off"
+)
 
-	req, err := s.client.NewRequest(http.MethodPost, systemPath, body)
-	if err != nil {
-		return nil, nil, err
-	}
+// LEDIndicatorModes lists the LED indicator modes known to the API
+var LEDIndicatorModes = []LEDIndicatorMode{
+	LEDIndicatorDemo, LEDIndicatorAutoBrightnessAdjust, LEDIndicatorDark, LEDIndicatorSimpleResponse, LEDIndicatorOff,
+}
 
-	result := new(GetPowerStatusResult)
-	resp, err := s.client.Do(req, result)
-	if err != nil {
-		return nil, resp, err
-	}
+// LEDIndicatorStatus represents the mode of the LED indicator and whether it is lit
+type LEDIndicatorStatus struct {
+	Mode   LEDIndicatorMode `json:"mode"`
+	Status string           `json:"status"` // "true" when the LED is lit, the TV reports a string
+}
+
+// WolMode represents whether the TV wakes up on Wake-on-LAN magic packets
+type WolMode struct {
+	Enabled bool `json:"enabled"`
+}
+
+// ModeError is returned when a mode isn't accepted, either because the API doesn't know it or
+// because the TV rejected it. Accepted lists the modes to choose from.
+type ModeError struct {
+	Setting  string   // Setting names what was rejected, e.g. "power saving mode"
+	Mode     string   // Mode is the rejected mode or value
+	Accepted []string // Accepted lists the modes known to the API
+	Err      error    // Err is the error returned by the TV, nil if the mode was rejected locally
+}
 
-	if result.HasError() {
-		return result, resp, errors.New(result.ErrorMessage())
+// Error implements the error interface
+func (e *ModeError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("bravia: this TV does not accept %s %q: %s", e.Setting, e.Mode, e.Err)
 	}
+	return fmt.Sprintf("bravia: unsupported %s %q, expected one of %s", e.Setting, e.Mode, strings.Join(e.Accepted, ", "))
+}
 
-	return result, resp, nil
+// Unwrap returns the error returned by the TV
+func (e *ModeError) Unwrap() error {
+	return e.Err
 }
 
-// GetCurrentTimeResult is the response from the getCurrentTime method
-type GetCurrentTimeResult = Result[[1]string]
+// ParsePowerSavingMode returns the power saving mode named s, ignoring case
+func ParsePowerSavingMode(s string) (PowerSavingMode, error) {
+	return parseMode("power saving mode", s, PowerSavingModes)
+}
 
-type getCurrentTimeParams [0]struct{}
-type getCurrentTimePayload Payload[getCurrentTimeParams]
+// ParseLEDIndicatorMode returns the LED indicator mode named s, ignoring case
+func ParseLEDIndicatorMode(s string) (LEDIndicatorMode, error) {
+	return parseMode("LED indicator mode", s, LEDIndicatorModes)
+}
 
-// GetCurrentTime returns the current time of the TV
-func (s *SystemService) GetCurrentTime() (*GetCurrentTimeResult, *http.Response, error) {
-	body := getCurrentTimePayload{
-		Method:  "getCurrentTime",
-		ID:      1,
-		Params:  getCurrentTimeParams{},
-		Version: "1.0",
+// parseMode returns the mode of modes named s, ignoring case
+func parseMode[M ~string](setting, s string, modes []M) (M, error) {
+	accepted := make([]string, len(modes))
+	for i, mode := range modes {
+		if strings.EqualFold(string(mode), s) {
+			return mode, nil
+		}
+		accepted[i] = string(mode)
 	}
+	return "", &ModeError{Setting: setting, Mode: s, Accepted: accepted}
+}
 
-	req, err := s.client.NewRequest(http.MethodPost, systemPath, body)
+// SetPowerSaving validates mode and sets the power saving mode. Modes the TV doesn't
+// support are reported as a *ModeError wrapping ErrIllegalArgument.
+func (s *SystemService) SetPowerSaving(ctx context.Context, mode string) (PowerSavingMode, error) {
+	m, err := ParsePowerSavingMode(mode)
 	if err != nil {
-		return nil, nil, err
+		return "", err
 	}
 
-	result := new(GetCurrentTimeResult)
-	resp, err := s.client.Do(req, result)
-	if err != nil {
-		return nil, resp, err
+	if _, _, err := s.SetPowerSavingMode(ctx, m); err != nil {
+		return "", rejectedMode(err, "power saving mode", string(m), PowerSavingModes)
 	}
+	return m, nil
+}
 
-	if result.HasError() {
-		return result, resp, errors.New(result.ErrorMessage())
+// SetLEDIndicator validates mode and sets the LED indicator mode. Modes the TV doesn't
+// support are reported as a *ModeError wrapping ErrIllegalArgument.
+func (s *SystemService) SetLEDIndicator(ctx context.Context, mode string) (LEDIndicatorMode, error) {
+	m, err := ParseLEDIndicatorMode(mode)
+	if err != nil {
+		return "", err
 	}
 
-	return result, resp, nil
+	if _, _, err := s.SetLEDIndicatorStatus(ctx, m, nil); err != nil {
+		return "", rejectedMode(err, "LED indicator mode", string(m), LEDIndicatorModes)
+	}
+	return m, nil
 }
 
-// RemoteCommand represents a remote control command
-type RemoteCommand struct {
-	Name  string `json:"name"`
-	Value string `json:"value"`
+// ScreenOff blanks the screen with the pictureOff power saving mode. Unlike standby,
+// the TV keeps playing, so the sound of apps and inputs can still be heard.
+func (s *SystemService) ScreenOff(ctx context.Context) error {
+	_, err := s.SetPowerSaving(ctx, string(PowerSavingPictureOff))
+	return err
 }
 
-// GetRemoteControllerInfoResult is the response from the getRemoteControllerInfo method
-type GetRemoteControllerInfoResult = Result[[2]interface{}]
-
-type getRemoteControllerInfoParams [0]struct{}
-type getRemoteControllerInfoPayload Payload[getRemoteControllerInfoParams]
-
-// GetRemoteControllerInfo returns information about the remote controller
-func (s *SystemService) GetRemoteControllerInfo() (*GetRemoteControllerInfoResult, *http.Response, error) {
-	body := getRemoteControllerInfoPayload{
-		Method:  "getRemoteControllerInfo",
-		ID:      1,
-		Params:  getRemoteControllerInfoParams{},
-		Version: "1.0",
+// ScreenOn turns the picture back on after ScreenOff by turning power saving off.
+// Any other power saving mode is left alone, since the picture is already on.
+func (s *SystemService) ScreenOn(ctx context.Context) error {
+	off, err := s.IsScreenOff(ctx)
+	if err != nil || !off {
+		return err
 	}
 
-	req, err := s.client.NewRequest(http.MethodPost, systemPath, body)
-	if err != nil {
-		return nil, nil, err
-	}
+	_, err = s.SetPowerSaving(ctx, string(PowerSavingOff))
+	return err
+}
 
-	result := new(GetRemoteControllerInfoResult)
-	resp, err := s.client.Do(req, result)
+// IsScreenOff reports whether the screen is blanked by the pictureOff power saving mode
+func (s *SystemService) IsScreenOff(ctx context.Context) (bool, error) {
+	result, _, err := s.GetPowerSavingMode(ctx)
 	if err != nil {
-		return nil, resp, err
+		return false, err
 	}
-
-	if result.HasError() {
-		return result, resp, errors.New(result.ErrorMessage())
+	if result.Result == nil || len(*result.Result) == 0 {
+		return false, errors.New("bravia: getPowerSavingMode returned no result")
 	}
-
-	return result, resp, nil
-}
-
-// InterfaceInformation represents TV interface information
-type InterfaceInformation struct {
-	ProductCategory  string `json:"productCategory"`
-	ProductName      string `json:"productName"`
-	ModelName        string `json:"modelName"`
-	ServerName       string `json:"serverName"`
-	InterfaceVersion string `json:"interfaceVersion"`
+	return (*result.Result)[0].PictureOff(), nil
 }
 
-// GetInterfaceInformationResult is the response from the getInterfaceInformation method
-type GetInterfaceInformationResult = Result[[1]InterfaceInformation]
-
-type getInterfaceInformationParams [0]struct{}
-type getInterfaceInformationPayload Payload[getInterfaceInformationParams]
+// rejectedMode wraps err in a *ModeError if the TV rejected the mode as an illegal argument
+func rejectedMode[M ~string](err error, setting, mode string, modes []M) error {
+	if !errors.Is(err, ErrIllegalArgument) {
+		return err
+	}
 
-// GetInterfaceInformation returns information about the TV interface
-func (s *SystemService) GetInterfaceInformation() (*GetInterfaceInformationResult, *http.Response, error) {
-	body := getInterfaceInformationPayload{
-		Method:  "getInterfaceInformation",
-		ID:      1,
-		Params:  getInterfaceInformationParams{},
-		Version: "1.0",
+	accepted := make([]string, len(modes))
+	for i, m := range modes {
+		accepted[i] = string(m)
 	}
+	return &ModeError{Setting: setting, Mode: mode, Accepted: accepted, Err: err}
+}
 
-	req, err := s.client.NewRequest(http.MethodPost, systemPath, body)
+// MACAddress returns the MAC address of the TV from getSystemInformation,
+// or from getNetworkSettings on models that don't report it there
+func (s *SystemService) MACAddress(ctx context.Context) (string, error) {
+	info, _, err := s.GetSystemInformation(ctx)
 	if err != nil {
-		return nil, nil, err
+		return "", err
+	}
+	if info.Result == nil {
+		return "", errors.New("bravia: getSystemInformation returned no result")
+	}
+	if mac := (*info.Result)[0].MACAddr; mac != "" {
+		return strings.ToLower(mac), nil
 	}
 
-	result := new(GetInterfaceInformationResult)
-	resp, err := s.client.Do(req, result)
+	settings, _, err := s.GetNetworkSettings(ctx, "")
 	if err != nil {
-		return nil, resp, err
+		return "", err
 	}
-
-	if result.HasError() {
-		return result, resp, errors.New(result.ErrorMessage())
+	if settings.Result == nil {
+		return "", errors.New("bravia: getNetworkSettings returned no result")
+	}
+	for _, netif := range (*settings.Result)[0] {
+		if netif.HWAddr != "" {
+			return strings.ToLower(netif.HWAddr), nil
+		}
 	}
 
-	return result, resp, nil
+	return "", errors.New("bravia: TV did not report a MAC address")
 }
 
-// RequestRebootResult is the response from the requestReboot method
-type RequestRebootResult = Result[[0]struct{}]
+// RemoteCommand represents a remote control command
+type RemoteCommand struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
 
-type requestRebootParams [0]struct{}
-type requestRebootPayload Payload[requestRebootParams]
+// RemoteControllerInfo represents the remote controller bundled with the TV and the IRCC codes it accepts
+type RemoteControllerInfo struct {
+	Bundled  bool            `json:"bundled"`
+	Type     string          `json:"type"`
+	Commands []RemoteCommand `json:"-"`
+}
 
-// RequestReboot requests a reboot of the TV
-func (s *SystemService) RequestReboot() (*RequestRebootResult, *http.Response, error) {
-	body := requestRebootPayload{
-		Method:  "requestReboot",
-		ID:      1,
-		Params:  requestRebootParams{},
-		Version: "1.0",
-	}
+// remoteControllerBundle is the first element of the getRemoteControllerInfo result
+type remoteControllerBundle struct {
+	Bundled bool   `json:"bundled"`
+	Type    string `json:"type"`
+}
 
-	req, err := s.client.NewRequest(http.MethodPost, systemPath, body)
-	if err != nil {
-		return nil, nil, err
+// UnmarshalJSON implements json.Unmarshaler, the TV returns the info as a [bundle, commands] pair
+func (r *RemoteControllerInfo) UnmarshalJSON(data []byte) error {
+	var result []json.RawMessage
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
 	}
-
-	result := new(RequestRebootResult)
-	resp, err := s.client.Do(req, result)
-	if err != nil {
-		return nil, resp, err
+	if len(result) < 2 {
+		return fmt.Errorf("bravia: expected 2 elements in remote controller info, got %d", len(result))
 	}
 
-	if result.HasError() {
-		return result, resp, errors.New(result.ErrorMessage())
+	var bundle remoteControllerBundle
+	if err := json.Unmarshal(result[0], &bundle); err != nil {
+		return err
+	}
+	var commands []RemoteCommand
+	if err := json.Unmarshal(result[1], &commands); err != nil {
+		return err
 	}
 
-	return result, resp, nil
+	*r = RemoteControllerInfo{Bundled: bundle.Bundled, Type: bundle.Type, Commands: commands}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, producing the [bundle, commands] pair returned by the TV
+func (r RemoteControllerInfo) MarshalJSON() ([]byte, error) {
+	bundle := remoteControllerBundle{Bundled: r.Bundled, Type: r.Type}
+	return json.Marshal([2]interface{}{bundle, r.Commands})
+}
+
+// InterfaceInformation represents TV interface information
+type InterfaceInformation struct {
+	ProductCategory  string `json:"productCategory"`
+	ProductName      string `json:"productName"`
+	ModelName        string `json:"modelName"`
+	ServerName       string `json:"serverName"`
+	InterfaceVersion string `json:"interfaceVersion"`
 }
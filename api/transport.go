@@ -0,0 +1,281 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting the TV while the circuit breaker is open,
+// after too many consecutive requests failed to reach it
+var ErrCircuitOpen = errors.New("bravia: circuit breaker open, TV is unreachable")
+
+// RetryPolicy configures how failed requests are retried. Requests that never reached the TV
+// are always retried, other failures only for idempotent requests such as get* methods.
+type RetryPolicy struct {
+	Attempts   int           // Maximum number of attempts, including the first one
+	MinBackoff time.Duration // Backoff before the first retry, doubled on each retry
+	MaxBackoff time.Duration // Upper bound of the backoff
+}
+
+// CircuitBreaker configures when requests fail fast with ErrCircuitOpen
+type CircuitBreaker struct {
+	Threshold int           // Number of consecutive failures that open the circuit
+	Cooldown  time.Duration // How long the circuit stays open before a request is let through again
+}
+
+// WithRetry returns an option retrying failed requests with jittered exponential backoff
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = &policy
+	}
+}
+
+// WithConcurrencyLimit returns an option limiting the number of requests in flight to each host
+func WithConcurrencyLimit(limit int) Option {
+	return func(c *Client) {
+		c.concurrencyLimit = limit
+	}
+}
+
+// WithCircuitBreaker returns an option failing fast with ErrCircuitOpen while the TV is unreachable
+func WithCircuitBreaker(breaker CircuitBreaker) Option {
+	return func(c *Client) {
+		c.breaker = &breaker
+	}
+}
+
+// middleware wraps transport with the retry, concurrency limit and circuit breaker transports
+// configured on the client. The order is fixed: the breaker sees a request once whatever the
+// number of retries, and each attempt waits for its own concurrency slot.
+func (c *Client) middleware(transport http.RoundTripper) http.RoundTripper {
+	if c.concurrencyLimit > 0 {
+		transport = &limitTransport{transport: transport, limit: c.concurrencyLimit}
+	}
+	if c.retry != nil && c.retry.Attempts > 1 {
+		transport = &retryTransport{transport: transport, policy: *c.retry}
+	}
+	if c.breaker != nil && c.breaker.Threshold > 0 {
+		transport = &breakerTransport{transport: transport, breaker: *c.breaker}
+	}
+	return transport
+}
+
+// idempotentKey is the context key marking a request as safe to send more than once
+type idempotentKey struct{}
+
+// withIdempotent marks requests sent with the returned context as idempotent
+func withIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+// isIdempotentMethod reports whether a JSON-RPC method only reads state
+func isIdempotentMethod(method string) bool {
+	return strings.HasPrefix(method, "get")
+}
+
+// isIdempotent reports whether req can safely be sent more than once
+func isIdempotent(req *http.Request) bool {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return true
+	}
+	idempotent, _ := req.Context().Value(idempotentKey{}).(bool)
+	return idempotent
+}
+
+// isDialError reports whether err happened while connecting, so the request never reached the TV
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// retryTransport is an http.RoundTripper that retries failed requests with jittered backoff
+type retryTransport struct {
+	// transport is the RoundTripper to use for the request
+	transport http.RoundTripper
+	policy    RetryPolicy
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := t.transport.RoundTrip(req)
+		if attempt >= t.policy.Attempts || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		// The body of the previous attempt was consumed, get a fresh one
+		retry := req.Clone(req.Context())
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			retry.Body = body
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(t.backoff(attempt)):
+		}
+		req = retry
+	}
+}
+
+// shouldRetry reports whether the outcome of an attempt is worth retrying
+func (t *retryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		return isDialError(err) || isIdempotent(req)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req)
+	}
+	return false
+}
+
+// backoff returns a random duration up to the exponential backoff for the given attempt
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	backoff := t.policy.MinBackoff << (attempt - 1)
+	if t.policy.MaxBackoff > 0 && (backoff > t.policy.MaxBackoff || backoff <= 0) {
+		backoff = t.policy.MaxBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	return rand.N(backoff) + 1
+}
+
+// limitTransport is an http.RoundTripper that limits the number of requests in flight to each host
+type limitTransport struct {
+	// transport is the RoundTripper to use for the request
+	transport http.RoundTripper
+	limit     int
+
+	mu    sync.Mutex
+	slots map[string]chan struct{}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	slots := t.hostSlots(req.URL.Host)
+
+	select {
+	case slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		<-slots
+		return nil, err
+	}
+
+	// Hold the slot until the response has been read
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: func() { <-slots }}
+	return resp, nil
+}
+
+// hostSlots returns the semaphore of host
+func (t *limitTransport) hostSlots(host string) chan struct{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.slots == nil {
+		t.slots = make(map[string]chan struct{})
+	}
+	slots, ok := t.slots[host]
+	if !ok {
+		slots = make(chan struct{}, t.limit)
+		t.slots[host] = slots
+	}
+	return slots
+}
+
+// releaseBody is a response body calling release once when closed
+type releaseBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+// Close implements io.Closer
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// breakerTransport is an http.RoundTripper that fails fast after consecutive failures to reach a host
+type breakerTransport struct {
+	// transport is the RoundTripper to use for the request
+	transport http.RoundTripper
+	breaker   CircuitBreaker
+
+	mu    sync.Mutex
+	hosts map[string]*circuit
+}
+
+// circuit tracks the failures of a single host
+type circuit struct {
+	failures  int
+	openUntil time.Time
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+
+	t.mu.Lock()
+	if t.hosts == nil {
+		t.hosts = make(map[string]*circuit)
+	}
+	c, ok := t.hosts[host]
+	if !ok {
+		c = &circuit{}
+		t.hosts[host] = c
+	}
+	if c.failures >= t.breaker.Threshold && time.Now().Before(c.openUntil) {
+		t.mu.Unlock()
+		return nil, ErrCircuitOpen
+	}
+	t.mu.Unlock()
+
+	resp, err := t.transport.RoundTrip(req)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Only failures to reach the TV count, errors it answers with show it's up.
+	// Requests canceled by the caller say nothing about the TV.
+	switch {
+	case err == nil:
+		c.failures = 0
+	case req.Context().Err() == nil:
+		c.failures++
+		if c.failures >= t.breaker.Threshold {
+			c.openUntil = time.Now().Add(t.breaker.Cooldown)
+		}
+	}
+
+	return resp, err
+}
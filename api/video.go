@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	videoPath       = "/sony/video"
+	videoScreenPath = "/sony/videoScreen"
+)
+
+// VideoService handles requests related to the picture, such as picture quality settings
+type VideoService service
+
+// Picture quality setting targets, not every model supports all of them
+const (
+	PictureMode             = "pictureMode"
+	PictureBrightness       = "brightness"
+	PictureContrast         = "contrast"
+	PictureColor            = "color"
+	PictureSharpness        = "sharpness"
+	PictureColorTemperature = "colorTemperature"
+	PictureMotionflow       = "motionflow"
+	PictureHDRMode          = "hdrMode"
+)
+
+// PictureQualitySetting represents a picture quality setting, its value and the values it accepts
+type PictureQualitySetting struct {
+	Target       string                    `json:"target"`
+	CurrentValue string                    `json:"currentValue"`
+	IsAvailable  bool                      `json:"isAvailable"` // Whether the setting can be changed in the current state
+	Candidate    []PictureQualityCandidate `json:"candidate,omitempty"`
+}
+
+// PictureQualityCandidate represents an accepted value of a picture quality setting,
+// either a named value or a numeric range
+type PictureQualityCandidate = Candidate
+
+// PictureQualityValue represents a value to set a picture quality setting to
+type PictureQualityValue struct {
+	Target string `json:"target"`
+	Value  string `json:"value"`
+}
+
+// ScreenRotation represents the rotation of the screen
+type ScreenRotation struct {
+	Angle int `json:"angle"` // Angle in degrees
+}
+
+// Validate returns value as accepted by the setting, matching named values regardless of case
+func (p PictureQualitySetting) Validate(value string) (string, error) {
+	if !p.IsAvailable {
+		return "", fmt.Errorf("bravia: picture setting %s can't be changed right now: %w", p.Target, ErrIllegalState)
+	}
+
+	_, value, err := matchCandidate(p.Target+" value", value, p.Candidate)
+	return value, err
+}
+
+// SetPictureQuality validates value against the candidates the TV returns for target and sets it,
+// returning the value as accepted by the TV
+func (s *VideoService) SetPictureQuality(ctx context.Context, target, value string) (string, error) {
+	result, _, err := s.GetPictureQualitySettings(ctx, target)
+	if err != nil {
+		return "", err
+	}
+	if result.Result == nil || len(*result.Result) == 0 {
+		return "", errors.New("bravia: getPictureQualitySettings returned no result")
+	}
+
+	var setting *PictureQualitySetting
+	settings := (*result.Result)[0]
+	for i := range settings {
+		if settings[i].Target == target {
+			setting = &settings[i]
+			break
+		}
+	}
+	if setting == nil {
+		return "", fmt.Errorf("bravia: picture setting %q is not supported by this TV: %w", target, ErrIllegalArgument)
+	}
+
+	value, err = setting.Validate(value)
+	if err != nil {
+		return "", err
+	}
+
+	if _, _, err := s.SetPictureQualitySettings(ctx, []PictureQualityValue{{Target: target, Value: value}}); err != nil {
+		return "", err
+	}
+	return value, nil
+}
@@ -0,0 +1,140 @@
+// Package wol wakes up TVs whose network stack is asleep by sending Wake-on-LAN magic packets.
+//
+//	err := wol.Send(ctx, "78:84:3c:ab:cd:ef", wol.WithBroadcast("192.168.1.255"))
+package wol
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultBroadcast = "255.255.255.255"
+	defaultPort      = 9
+	defaultRepeat    = 3
+	defaultInterval  = 100 * time.Millisecond
+)
+
+// options holds the settings used to send magic packets
+type options struct {
+	broadcast string
+	port      int
+	repeat    int
+	interval  time.Duration
+}
+
+// Option configures how magic packets are sent
+type Option func(*options)
+
+// WithBroadcast sets the broadcast address to send the packet to, 255.255.255.255 by default
+func WithBroadcast(address string) Option {
+	return func(o *options) {
+		o.broadcast = address
+	}
+}
+
+// WithPort sets the UDP port to send the packet to, 9 by default
+func WithPort(port int) Option {
+	return func(o *options) {
+		o.port = port
+	}
+}
+
+// WithRepeat sets how many times the packet is sent, 3 by default, since UDP may drop it
+func WithRepeat(repeat int) Option {
+	return func(o *options) {
+		o.repeat = repeat
+	}
+}
+
+// WithInterval sets the time to wait between repeated packets
+func WithInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.interval = interval
+	}
+}
+
+// Settings holds the Wake-on-LAN settings as found in a config file, zero values keep the defaults
+type Settings struct {
+	Broadcast string // Broadcast address, see WithBroadcast
+	Port      int    // UDP port, see WithPort
+	Repeat    int    // Number of packets, see WithRepeat
+}
+
+// Options returns the options for the settings that are set
+func (s Settings) Options() []Option {
+	var opts []Option
+	if s.Broadcast != "" {
+		opts = append(opts, WithBroadcast(s.Broadcast))
+	}
+	if s.Port != 0 {
+		opts = append(opts, WithPort(s.Port))
+	}
+	if s.Repeat != 0 {
+		opts = append(opts, WithRepeat(s.Repeat))
+	}
+	return opts
+}
+
+// MagicPacket returns the magic packet waking up the device with the given MAC address:
+// six 0xff bytes followed by the MAC address repeated sixteen times
+func MagicPacket(mac string) ([]byte, error) {
+	hw, err := net.ParseMAC(mac)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAC address %q: %w", mac, err)
+	}
+	if len(hw) != 6 {
+		return nil, fmt.Errorf("invalid MAC address %q: expected 6 bytes, got %d", mac, len(hw))
+	}
+
+	packet := bytes.Repeat([]byte{0xff}, 6)
+	for i := 0; i < 16; i++ {
+		packet = append(packet, hw...)
+	}
+	return packet, nil
+}
+
+// Send broadcasts the magic packet for the given MAC address
+func Send(ctx context.Context, mac string, opts ...Option) error {
+	o := &options{
+		broadcast: defaultBroadcast,
+		port:      defaultPort,
+		repeat:    defaultRepeat,
+		interval:  defaultInterval,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	packet, err := MagicPacket(mac)
+	if err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp4", net.JoinHostPort(o.broadcast, strconv.Itoa(o.port)))
+	if err != nil {
+		return fmt.Errorf("failed to open socket: %w", err)
+	}
+	defer conn.Close()
+
+	for i := 0; i < max(o.repeat, 1); i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(o.interval):
+			}
+		}
+
+		if _, err := conn.Write(packet); err != nil {
+			return fmt.Errorf("failed to send magic packet: %w", err)
+		}
+	}
+
+	return nil
+}
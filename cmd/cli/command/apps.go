@@ -29,10 +29,9 @@ var appsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List apps on your TV",
 	Run: func(cmd *cobra.Command, args []string) {
-		result, _, err := client.AppControl.GetApplicationList()
+		result, _, err := client.AppControl.GetApplicationList(cmd.Context())
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-			os.Exit(1)
+			exitWithError(err)
 		}
 
 		apps := result.Result[0]
@@ -60,22 +59,19 @@ var appsOpenCmd = &cobra.Command{
 		if cmd.Flags().Changed("uri") {
 			value, err := cmd.Flags().GetString("uri")
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-				os.Exit(1)
+				exitWithError(err)
 			}
 			uri = value
 		} else {
 			name, err := cmd.Flags().GetString("name")
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-				os.Exit(1)
+				exitWithError(err)
 			}
 
 			// Get list of apps
-			result, _, err := client.AppControl.GetApplicationList()
+			result, _, err := client.AppControl.GetApplicationList(cmd.Context())
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-				os.Exit(1)
+				exitWithError(err)
 			}
 			apps := result.Result[0]
 
@@ -101,10 +97,9 @@ var appsOpenCmd = &cobra.Command{
 			fmt.Printf("Found app: %s (URI: %s)\n", matchedTitle, uri)
 		}
 
-		_, _, err := client.AppControl.SetActiveApp(uri, nil)
+		_, _, err := client.AppControl.SetActiveApp(cmd.Context(), uri, nil)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-			os.Exit(1)
+			exitWithError(err)
 		}
 	},
 }
@@ -0,0 +1,178 @@
+package command
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lithammer/fuzzysearch/fuzzy"
+	"github.com/spf13/cobra"
+	"github.com/trugamr/bravia/api"
+)
+
+func init() {
+	rootCmd.AddCommand(browseCmd)
+
+	// Define flags for the browse command
+	addOutputFlag(browseCmd)
+	browseCmd.Flags().IntP("limit", "l", 0, "Maximum number of content items to list, 0 for all")
+}
+
+var browseCmd = &cobra.Command{
+	Use:   "browse [scheme|source] [entry]",
+	Short: "Browse and play the content of your TV",
+	Long: `Walks the content of the TV from schemes to sources to content items.
+Without arguments the schemes are listed, such as tv or extInput. Given a scheme its sources
+are listed, such as tv:dvbt or extInput:hdmi, and given a source its content items are listed.
+Given a source and an entry, plays the item with that number in the list or the closest title.`,
+	Example: `  bravia browse
+  bravia browse tv
+  bravia browse tv:dvbt
+  bravia browse tv:dvbt "bbc news"
+  bravia browse extInput:hdmi 2`,
+	Args: cobra.MaximumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		output := outputFormat(cmd)
+
+		switch {
+		case len(args) == 0:
+			browseSchemes(cmd, output)
+		case !strings.Contains(args[0], ":"):
+			if len(args) == 2 {
+				exitWithError(fmt.Errorf("entries can only be played from a source, such as %s:...", args[0]))
+			}
+			browseSources(cmd, output, args[0])
+		case len(args) == 1:
+			browseContent(cmd, output, args[0])
+		default:
+			playContent(cmd, args[0], args[1])
+		}
+	},
+}
+
+// browseSchemes lists the content schemes of the TV
+func browseSchemes(cmd *cobra.Command, output string) {
+	result, _, err := client.AVContent.GetSchemeList(cmd.Context())
+	if err != nil {
+		exitWithError(err)
+	}
+
+	schemes := result.Result[0]
+	if output == outputJSON {
+		printJSON(schemes)
+		return
+	}
+	for _, scheme := range schemes {
+		fmt.Println(scheme.Scheme)
+	}
+}
+
+// browseSources lists the sources of a content scheme
+func browseSources(cmd *cobra.Command, output, scheme string) {
+	result, _, err := client.AVContent.GetSourceList(cmd.Context(), scheme)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	sources := result.Result[0]
+	if output == outputJSON {
+		printJSON(sources)
+		return
+	}
+	for _, source := range sources {
+		fmt.Println(source.Source)
+	}
+}
+
+// browseContent lists the content items of a source, paging through them as they are printed
+func browseContent(cmd *cobra.Command, output, source string) {
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		exitWithError(err)
+	}
+
+	// Stopping the loop early at the limit saves fetching the remaining pages
+	items := []api.ContentItem{}
+	for item, err := range client.AVContent.ContentItems(cmd.Context(), source) {
+		if err != nil {
+			exitWithError(err)
+		}
+
+		items = append(items, item)
+		if len(items) == limit {
+			break
+		}
+	}
+
+	if output == outputJSON {
+		printJSON(items)
+		return
+	}
+
+	table := newTable()
+	for _, item := range items {
+		fmt.Fprintf(table, "%3d.\t%s\t%s\t[URI: %s]\n", item.Index+1, contentNumber(item), item.Title, item.URI)
+	}
+	table.Flush()
+}
+
+// playContent plays the content item of a source given by its number in the list or its title
+func playContent(cmd *cobra.Command, source, entry string) {
+	var items []api.ContentItem
+	for item, err := range client.AVContent.ContentItems(cmd.Context(), source) {
+		if err != nil {
+			exitWithError(err)
+		}
+
+		// Stop as soon as the entry's number is reached, no need to fetch the rest of the list
+		if n, err := strconv.Atoi(entry); err == nil && item.Index+1 == n {
+			items = []api.ContentItem{item}
+			break
+		}
+		items = append(items, item)
+	}
+
+	item, err := findContent(items, entry)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if _, _, err := client.AVContent.SetPlayContent(cmd.Context(), item.URI); err != nil {
+		exitWithError(err)
+	}
+	fmt.Printf("Playing %s (URI: %s)\n", item.Title, item.URI)
+}
+
+// findContent returns the item numbered entry, or the item whose title matches entry best
+func findContent(items []api.ContentItem, entry string) (api.ContentItem, error) {
+	if n, err := strconv.Atoi(entry); err == nil {
+		for _, item := range items {
+			if item.Index+1 == n {
+				return item, nil
+			}
+		}
+	}
+
+	titles := make([]string, len(items))
+	for i, item := range items {
+		titles[i] = item.Title
+	}
+
+	matches := fuzzy.RankFindFold(entry, titles)
+	if len(matches) == 0 {
+		return api.ContentItem{}, fmt.Errorf("no content matching %q", entry)
+	}
+	sort.Sort(matches)
+
+	return items[matches[0].OriginalIndex], nil
+}
+
+// contentNumber returns the number the TV shows for a channel, or "-" for other content
+func contentNumber(item api.ContentItem) string {
+	if item.DispNum == nil {
+		return "-"
+	}
+	return cmp.Or(*item.DispNum, "-")
+}
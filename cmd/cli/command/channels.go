@@ -0,0 +1,173 @@
+package command
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/trugamr/bravia/api"
+)
+
+func init() {
+	channelsCmd.AddCommand(channelsListCmd, channelsTuneCmd)
+
+	rootCmd.AddCommand(channelsCmd)
+
+	// Define flags for the channels commands
+	channelsCmd.PersistentFlags().BoolP("refresh", "r", false, "Read the channels from the TV instead of the cache")
+	addOutputFlag(channelsListCmd)
+}
+
+var channelsCmd = &cobra.Command{
+	Use:   "channels",
+	Short: "List and tune broadcast channels on your TV",
+	Long: `Allows you to list broadcast channels and tune to them by number or name.
+The channels are read from the TV once and cached in $HOME/.bravia/channels.json,
+use --refresh after a channel scan to read them again.`,
+}
+
+var channelsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List broadcast channels on your TV",
+	Run: func(cmd *cobra.Command, args []string) {
+		output := outputFormat(cmd)
+		index, _ := channelIndex(cmd)
+
+		if output == outputJSON {
+			printJSON(index.Channels)
+			return
+		}
+
+		if len(index.Channels) == 0 {
+			fmt.Println("No channels found, the TV may not have a tuner or a channel scan")
+			return
+		}
+
+		table := newTable()
+		for _, channel := range index.Channels {
+			fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", cmp.Or(channel.DispNum, "-"), channel.Name, channel.Source, channel.TripletStr)
+		}
+		table.Flush()
+	},
+}
+
+var channelsTuneCmd = &cobra.Command{
+	Use:   "tune <number|name>",
+	Short: "Tune to a broadcast channel",
+	Long: `Tunes to the channel with the given number, or whose name matches best.
+Leading zeros of numbers are ignored and names are matched fuzzily, e.g. "bbcnews".`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		index, cached := channelIndex(cmd)
+
+		channel, err := index.Find(args[0])
+		if errors.Is(err, api.ErrChannelNotFound) && cached {
+			// The channel may have been added since the cache was written
+			index = scanChannels(cmd)
+			channel, err = index.Find(args[0])
+		}
+		if err != nil {
+			exitWithError(err)
+		}
+
+		if _, _, err := client.AVContent.SetPlayContent(cmd.Context(), channel.URI); err != nil {
+			exitWithError(err)
+		}
+		fmt.Printf("Tuned to %s %s\n", channel.DispNum, channel.Name)
+	},
+}
+
+// refreshChannels reports whether the --refresh flag was given
+func refreshChannels(cmd *cobra.Command) bool {
+	refresh, err := cmd.Flags().GetBool("refresh")
+	if err != nil {
+		exitWithError(err)
+	}
+	return refresh
+}
+
+// channelIndex returns the cached channel index, scanning the channels of the TV when
+// there is no cache for it or --refresh is given. It reports whether the cache was used.
+func channelIndex(cmd *cobra.Command) (*api.ChannelIndex, bool) {
+	if !refreshChannels(cmd) {
+		if index, err := readChannelCache(); err == nil {
+			return index, true
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "Ignoring channel cache: %s\n", err)
+		}
+	}
+	return scanChannels(cmd), false
+}
+
+// scanChannels reads the channels of the TV and caches them
+func scanChannels(cmd *cobra.Command) *api.ChannelIndex {
+	index, err := client.AVContent.NewChannelIndex(cmd.Context())
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if err := writeChannelCache(index); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to cache channels: %s\n", err)
+	}
+	return index
+}
+
+// channelCache is the channel index as cached on disk, for the TV at BaseURL
+type channelCache struct {
+	BaseURL string `json:"baseUrl"`
+	api.ChannelIndex
+}
+
+// channelCachePath returns the path of the channel cache
+func channelCachePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".bravia", "channels.json"), nil
+}
+
+// readChannelCache returns the cached channel index, fs.ErrNotExist if there is none for the TV
+func readChannelCache() (*api.ChannelIndex, error) {
+	path, err := channelCachePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cache channelCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	if cache.BaseURL != cfg.BaseURL {
+		// The cache belongs to another TV
+		return nil, fs.ErrNotExist
+	}
+	return &cache.ChannelIndex, nil
+}
+
+// writeChannelCache caches the channel index for the TV
+func writeChannelCache(index *api.ChannelIndex) error {
+	path, err := channelCachePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(channelCache{BaseURL: cfg.BaseURL, ChannelIndex: *index}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
@@ -0,0 +1,87 @@
+package command
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/trugamr/bravia/api/discovery"
+)
+
+func init() {
+	rootCmd.AddCommand(discoverCmd)
+
+	// Define flags for the discover command
+	discoverCmd.Flags().DurationP("timeout", "t", 3*time.Second, "How long to wait for TVs to answer")
+	discoverCmd.Flags().String("address", "", "Send the search to this host:port instead of the SSDP multicast group")
+	discoverCmd.Flags().IntP("save", "s", 0, "Save the TV with this number from the list into the config file")
+}
+
+var discoverCmd = &cobra.Command{
+	Use:   "discover",
+	Short: "Find Bravia TVs on your network",
+	Long: `Searches the local network for Bravia TVs using SSDP and lists the ones found.
+TVs are listed by name, so the numbers stay the same between runs.
+Use --save to write the base URL and MAC address of one of them into the config file.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		timeout, err := cmd.Flags().GetDuration("timeout")
+		if err != nil {
+			exitWithError(err)
+		}
+		address, err := cmd.Flags().GetString("address")
+		if err != nil {
+			exitWithError(err)
+		}
+		save, err := cmd.Flags().GetInt("save")
+		if err != nil {
+			exitWithError(err)
+		}
+
+		var opts []discovery.Option
+		if address != "" {
+			opts = append(opts, discovery.WithAddress(address))
+		}
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+		defer cancel()
+
+		devices, err := discovery.Discover(ctx, opts...)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		if len(devices) == 0 {
+			fmt.Fprintln(os.Stderr, "No TVs found")
+			os.Exit(exitError)
+		}
+
+		for i, device := range devices {
+			name := cmp.Or(device.FriendlyName, device.ModelName, "-")
+			mac := cmp.Or(device.MACAddress, "-")
+			fmt.Printf("%2d. %s (Model: %s) [Base URL: %s] [MAC: %s]\n", i+1, name, device.ModelName, device.BaseURL, mac)
+		}
+
+		if !cmd.Flags().Changed("save") {
+			return
+		}
+
+		if save < 1 || save > len(devices) {
+			exitWithError(fmt.Errorf("--save must be between 1 and %d", len(devices)))
+		}
+
+		// The MAC address lets "bravia power on" wake the TV with Wake-on-LAN
+		device := devices[save-1]
+		settings := map[string]interface{}{"base_url": device.BaseURL}
+		if device.MACAddress != "" {
+			settings["mac_address"] = device.MACAddress
+		}
+		if err := cfg.Write(settings); err != nil {
+			exitWithError(err)
+		}
+
+		fmt.Printf("Saved %s (%s) to config\n", cmp.Or(device.FriendlyName, device.ModelName), device.BaseURL)
+	},
+}
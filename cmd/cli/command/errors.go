@@ -0,0 +1,37 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/trugamr/bravia/api"
+)
+
+// Exit codes used when a command fails
+const (
+	exitError       = 1 // Generic failure
+	exitForbidden   = 3 // The TV rejected our credentials
+	exitUnsupported = 4 // The TV does not support the method or version
+	exitUnavailable = 5 // The TV is in a state where the request can't be served
+)
+
+// exitCode returns the process exit code for the given error
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, api.ErrForbidden), errors.Is(err, api.ErrUnauthorized):
+		return exitForbidden
+	case api.IsUnsupported(err):
+		return exitUnsupported
+	case errors.Is(err, api.ErrDisplayOff), errors.Is(err, api.ErrIllegalState), errors.Is(err, api.ErrInputUnavailable):
+		return exitUnavailable
+	default:
+		return exitError
+	}
+}
+
+// exitWithError prints the error and exits with the code matching it
+func exitWithError(err error) {
+	fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+	os.Exit(exitCode(err))
+}
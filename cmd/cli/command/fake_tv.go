@@ -0,0 +1,54 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/trugamr/bravia/api/bravatest"
+)
+
+func init() {
+	rootCmd.AddCommand(fakeTVCmd)
+
+	// Define flags for the fake-tv command
+	fakeTVCmd.Flags().StringP("addr", "a", "localhost:8081", "Address to listen on")
+	fakeTVCmd.Flags().String("tv-psk", "", "Pre-shared key the fake TV requires (empty disables the check)")
+	fakeTVCmd.Flags().Bool("standby", false, "Start the fake TV in standby")
+}
+
+var fakeTVCmd = &cobra.Command{
+	Use:   "fake-tv",
+	Short: "Run a fake Bravia TV for offline development",
+	Long: `Serves an in-memory emulation of the Bravia API, including the IRCC endpoint,
+so the CLI and the web remote can be used without a real TV.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		addr, err := cmd.Flags().GetString("addr")
+		if err != nil {
+			exitWithError(err)
+		}
+		psk, err := cmd.Flags().GetString("tv-psk")
+		if err != nil {
+			exitWithError(err)
+		}
+		standby, err := cmd.Flags().GetBool("standby")
+		if err != nil {
+			exitWithError(err)
+		}
+
+		tv := bravatest.New(bravatest.WithPSK(psk), bravatest.WithPower(!standby))
+		server := &http.Server{Addr: addr, Handler: tv}
+
+		// Shut down when the command is interrupted
+		go func() {
+			<-cmd.Context().Done()
+			server.Close()
+		}()
+
+		fmt.Printf("Fake Bravia TV listening on http://%s\n", addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			exitWithError(err)
+		}
+	},
+}
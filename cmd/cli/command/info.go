@@ -0,0 +1,106 @@
+package command
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/trugamr/bravia/api"
+)
+
+func init() {
+	rootCmd.AddCommand(infoCmd)
+
+	// Define flags for the info command
+	addOutputFlag(infoCmd)
+}
+
+// systemInfo gathers the information shown by the info command
+type systemInfo struct {
+	System    *api.SystemInformation    `json:"system,omitempty"`
+	Interface *api.InterfaceInformation `json:"interface,omitempty"`
+	Network   []api.NetworkSettings     `json:"network,omitempty"`
+	Functions []api.SupportedFunction   `json:"functions,omitempty"`
+}
+
+var infoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Show information about the TV",
+	Long: `Shows the model, serial number, software generation, network settings
+and supported functions of the TV.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		output := outputFormat(cmd)
+
+		var info systemInfo
+
+		system, _, err := client.System.GetSystemInformation(cmd.Context())
+		if err != nil {
+			exitWithError(err)
+		}
+		info.System = &system.Result[0]
+
+		// The remaining calls aren't supported by every model, show what is available
+		iface, _, err := client.System.GetInterfaceInformation(cmd.Context())
+		if err == nil {
+			info.Interface = &iface.Result[0]
+		} else if !api.IsUnsupported(err) {
+			exitWithError(err)
+		}
+
+		network, _, err := client.System.GetNetworkSettings(cmd.Context(), "")
+		if err == nil {
+			info.Network = network.Result[0]
+		} else if !api.IsUnsupported(err) {
+			exitWithError(err)
+		}
+
+		functions, _, err := client.System.GetSystemSupportedFunction(cmd.Context())
+		if err == nil {
+			info.Functions = functions.Result[0]
+		} else if !api.IsUnsupported(err) {
+			exitWithError(err)
+		}
+
+		if output == outputJSON {
+			printJSON(info)
+			return
+		}
+
+		table := newTable()
+		fmt.Fprintf(table, "Model:\t%s\n", info.System.Model)
+		fmt.Fprintf(table, "Name:\t%s\n", info.System.Name)
+		fmt.Fprintf(table, "Product:\t%s\n", info.System.Product)
+		fmt.Fprintf(table, "Serial:\t%s\n", cmp.Or(info.System.Serial, "-"))
+		fmt.Fprintf(table, "MAC address:\t%s\n", cmp.Or(info.System.MACAddr, "-"))
+		fmt.Fprintf(table, "Generation:\t%s\n", info.System.Generation)
+		fmt.Fprintf(table, "Area:\t%s\n", info.System.Area)
+		fmt.Fprintf(table, "Region:\t%s\n", info.System.Region)
+		fmt.Fprintf(table, "Language:\t%s\n", info.System.Language)
+		if info.Interface != nil {
+			fmt.Fprintf(table, "API version:\t%s\n", info.Interface.InterfaceVersion)
+		}
+		table.Flush()
+
+		if len(info.Network) > 0 {
+			fmt.Println()
+			table = newTable()
+			fmt.Fprintln(table, "INTERFACE\tIP\tMAC\tGATEWAY\tDNS")
+			for _, netif := range info.Network {
+				dns := cmp.Or(strings.Join(netif.DNS, ", "), "-")
+				fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\n", netif.Netif, cmp.Or(netif.IPAddrV4, "-"), cmp.Or(netif.HWAddr, "-"), cmp.Or(netif.Gateway, "-"), dns)
+			}
+			table.Flush()
+		}
+
+		if len(info.Functions) > 0 {
+			fmt.Println()
+			table = newTable()
+			fmt.Fprintln(table, "FUNCTION\tSUPPORT")
+			for _, function := range info.Functions {
+				fmt.Fprintf(table, "%s\t%s\n", function.Option, function.Value)
+			}
+			table.Flush()
+		}
+	},
+}
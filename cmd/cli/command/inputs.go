@@ -32,10 +32,9 @@ var inputsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List external inputs on your TV",
 	Run: func(cmd *cobra.Command, args []string) {
-		result, _, err := client.AVContent.GetCurrentExternalInputsStatus()
+		result, _, err := client.AVContent.GetCurrentExternalInputsStatus(cmd.Context())
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-			os.Exit(1)
+			exitWithError(err)
 		}
 
 		inputs := result.Result[0]
@@ -81,10 +80,9 @@ var inputsSelectCmd = &cobra.Command{
 
 		// Helper to find the URI based on fuzzy matching
 		findURI := func(input string, keySelector func(input api.ExternalInputStatus) string) string {
-			result, _, err := client.AVContent.GetCurrentExternalInputsStatus()
+			result, _, err := client.AVContent.GetCurrentExternalInputsStatus(cmd.Context())
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error fetching inputs: %s\n", err)
-				os.Exit(1)
+				exitWithError(fmt.Errorf("failed to fetch inputs: %w", err))
 			}
 			inputs := result.Result[0]
 
@@ -122,10 +120,9 @@ var inputsSelectCmd = &cobra.Command{
 			uri = findURI(label, func(input api.ExternalInputStatus) string { return input.Label })
 		}
 
-		_, _, err := client.AVContent.SetPlayContent(uri)
+		_, _, err := client.AVContent.SetPlayContent(cmd.Context(), uri)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-			os.Exit(1)
+			exitWithError(err)
 		}
 
 		fmt.Println("Selected input:", uri)
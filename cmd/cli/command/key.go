@@ -0,0 +1,154 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/trugamr/bravia/api"
+)
+
+func init() {
+	rootCmd.AddCommand(keyCmd)
+
+	// Define flags for the key command
+	keyCmd.Flags().BoolP("list", "l", false, "List the available key names")
+	keyCmd.Flags().DurationP("delay", "d", 300*time.Millisecond, "Delay between keys")
+}
+
+var keyCmd = &cobra.Command{
+	Use:   "key <name>...",
+	Short: "Send remote control keys to the TV",
+	Long: `Sends remote control keys to the TV, one after the other.
+
+Keys are given by name, ignoring case, or as raw base64 IRCC codes. The names
+come from the TV, so --list shows the keys supported by your model.
+A key followed by xN is sent N times, and a duration pauses the sequence:
+
+  bravia key home down down confirm
+  bravia key volumeup x5
+  bravia key input 2s confirm`,
+	Run: func(cmd *cobra.Command, args []string) {
+		list, err := cmd.Flags().GetBool("list")
+		if err != nil {
+			exitWithError(err)
+		}
+		delay, err := cmd.Flags().GetDuration("delay")
+		if err != nil {
+			exitWithError(err)
+		}
+
+		if list {
+			codes, err := client.IRCC.Codes(cmd.Context())
+			if err != nil {
+				exitWithError(err)
+			}
+			for _, name := range codes.Names() {
+				fmt.Println(name)
+			}
+			return
+		}
+
+		if len(args) == 0 {
+			exitWithError(fmt.Errorf("at least one key is required, use --list to show the available keys"))
+		}
+
+		steps, err := parseKeySequence(cmd.Context(), args)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		for i, step := range steps {
+			if step.pause > 0 {
+				if err := sleep(cmd, step.pause); err != nil {
+					exitWithError(err)
+				}
+				continue
+			}
+
+			if i > 0 && steps[i-1].pause == 0 {
+				if err := sleep(cmd, delay); err != nil {
+					exitWithError(err)
+				}
+			}
+
+			_, err := client.IRCC.SendIRCCCommand(cmd.Context(), string(step.command))
+			if err != nil {
+				exitWithError(fmt.Errorf("failed to send %s: %w", step.name, err))
+			}
+		}
+	},
+}
+
+// keyStep is a single step of a key sequence, either a key to send or a pause
+type keyStep struct {
+	name    string
+	command api.IRCCCommand
+	pause   time.Duration
+}
+
+// parseKeySequence turns the arguments of the key command into the steps to perform,
+// resolving key names against the codes supported by the TV
+func parseKeySequence(ctx context.Context, args []string) ([]keyStep, error) {
+	var steps []keyStep
+	for _, arg := range args {
+		// Repeat the previous key
+		if n, ok := parseRepeat(arg); ok {
+			if len(steps) == 0 || steps[len(steps)-1].pause > 0 {
+				return nil, fmt.Errorf("%s must follow a key", arg)
+			}
+			previous := steps[len(steps)-1]
+			for i := 1; i < n; i++ {
+				steps = append(steps, previous)
+			}
+			continue
+		}
+
+		if pause, err := time.ParseDuration(arg); err == nil {
+			// Steps without a pause are keys, so a zero pause would be sent as an empty key
+			if pause <= 0 {
+				return nil, fmt.Errorf("pause %s must be positive", arg)
+			}
+			steps = append(steps, keyStep{name: arg, pause: pause})
+			continue
+		}
+
+		command, err := client.IRCC.Resolve(ctx, arg)
+		if errors.Is(err, api.ErrUnknownCommand) {
+			return nil, fmt.Errorf("unknown key %q, use --list to show the keys supported by your TV", arg)
+		}
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, keyStep{name: arg, command: command})
+	}
+
+	return steps, nil
+}
+
+// parseRepeat parses a repeat count of the form xN
+func parseRepeat(arg string) (int, bool) {
+	count, ok := strings.CutPrefix(strings.ToLower(arg), "x")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.Atoi(count)
+	if err != nil || n < 1 {
+		return 0, false
+	}
+	return n, true
+}
+
+// sleep waits for d, returning early if the command is interrupted
+func sleep(cmd *cobra.Command, d time.Duration) error {
+	select {
+	case <-cmd.Context().Done():
+		return cmd.Context().Err()
+	case <-time.After(d):
+		return nil
+	}
+}
@@ -0,0 +1,45 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseKeySequencePauses(t *testing.T) {
+	tests := []struct {
+		args    []string
+		want    []time.Duration
+		wantErr bool
+	}{
+		{args: []string{"2s"}, want: []time.Duration{2 * time.Second}},
+		{args: []string{"500ms", "1s"}, want: []time.Duration{500 * time.Millisecond, time.Second}},
+		{args: []string{"0s"}, wantErr: true},
+		{args: []string{"-1s"}, wantErr: true},
+		{args: []string{"1s", "x2"}, wantErr: true},
+		{args: []string{"x2"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		steps, err := parseKeySequence(context.Background(), tt.args)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseKeySequence(%q) error = nil, want an error", tt.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseKeySequence(%q) error = %v", tt.args, err)
+			continue
+		}
+
+		if len(steps) != len(tt.want) {
+			t.Fatalf("parseKeySequence(%q) = %d steps, want %d", tt.args, len(steps), len(tt.want))
+		}
+		for i, step := range steps {
+			if step.pause != tt.want[i] || step.command != "" {
+				t.Errorf("parseKeySequence(%q)[%d] = %+v, want a pause of %s", tt.args, i, step, tt.want[i])
+			}
+		}
+	}
+}
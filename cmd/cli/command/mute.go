@@ -0,0 +1,58 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(muteCmd)
+}
+
+var muteCmd = &cobra.Command{
+	Use:   "mute <on|off|toggle>",
+	Short: "Mute or unmute the TV",
+	Long: `Mutes or unmutes the TV. toggle reads whether the TV is muted first and
+does the opposite.`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"on", "off", "toggle"},
+	Run: func(cmd *cobra.Command, args []string) {
+		var mute bool
+		switch args[0] {
+		case "on":
+			mute = true
+		case "off":
+			mute = false
+		case "toggle":
+			result, _, err := client.Audio.GetVolumeInformation(cmd.Context())
+			if err != nil {
+				exitWithError(err)
+			}
+
+			// The TV mutes every target at once, go by the speakers if they are listed
+			volumes := result.Result[0]
+			if len(volumes) == 0 {
+				exitWithError(fmt.Errorf("the TV did not report its volume"))
+			}
+			muted := volumes[0].Mute
+			for _, info := range volumes {
+				if info.Target == "speaker" {
+					muted = info.Mute
+				}
+			}
+			mute = !muted
+		default:
+			exitWithError(fmt.Errorf("invalid mute state %q, expected on, off or toggle", args[0]))
+		}
+
+		if _, _, err := client.Audio.SetAudioMute(cmd.Context(), mute); err != nil {
+			exitWithError(err)
+		}
+		if mute {
+			fmt.Println("Muted")
+		} else {
+			fmt.Println("Unmuted")
+		}
+	},
+}
@@ -0,0 +1,64 @@
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(nowCmd)
+
+	// Define flags for the now command
+	addOutputFlag(nowCmd)
+}
+
+var nowCmd = &cobra.Command{
+	Use:   "now",
+	Short: "Show what is playing on the TV",
+	Long: `Shows the input or channel being played, with the current program for channels.
+Apps don't report what they play, so nothing is shown while an app is in the foreground.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		output := outputFormat(cmd)
+
+		info, err := client.AVContent.NowPlaying(cmd.Context())
+		if err != nil {
+			exitWithError(err)
+		}
+
+		if output == outputJSON {
+			printJSON(info)
+			return
+		}
+
+		if info == nil {
+			result, _, err := client.System.GetPowerStatus(cmd.Context())
+			if err == nil && result.Result[0].Status != "active" {
+				fmt.Println("The TV is in standby")
+				return
+			}
+			fmt.Println("Nothing is playing from an input or channel, an app may be in the foreground")
+			return
+		}
+
+		table := newTable()
+		fmt.Fprintf(table, "Title:\t%s\n", info.Title)
+		if info.DispNum != "" {
+			fmt.Fprintf(table, "Channel:\t%s\n", info.DispNum)
+		}
+		if info.ProgramTitle != "" {
+			fmt.Fprintf(table, "Program:\t%s\n", info.ProgramTitle)
+		}
+		if info.StartDateTime != "" {
+			start := info.StartDateTime
+			if info.DurationSec > 0 {
+				start += fmt.Sprintf(" (%s)", time.Duration(info.DurationSec)*time.Second)
+			}
+			fmt.Fprintf(table, "Started:\t%s\n", start)
+		}
+		fmt.Fprintf(table, "Source:\t%s\n", info.Source)
+		fmt.Fprintf(table, "URI:\t%s\n", info.URI)
+		table.Flush()
+	},
+}
@@ -0,0 +1,47 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// Output formats accepted by the --output flag
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// addOutputFlag defines the --output flag on cmd
+func addOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("output", "o", outputTable, "Output format, either table or json")
+}
+
+// outputFormat returns the validated value of the --output flag
+func outputFormat(cmd *cobra.Command) string {
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		exitWithError(err)
+	}
+	if output != outputTable && output != outputJSON {
+		exitWithError(fmt.Errorf("invalid output format %q, expected table or json", output))
+	}
+	return output
+}
+
+// printJSON prints v as indented JSON
+func printJSON(v interface{}) {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		exitWithError(err)
+	}
+}
+
+// newTable returns a writer aligning tab separated columns, to be flushed once written
+func newTable() *tabwriter.Writer {
+	return tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+}
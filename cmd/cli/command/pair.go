@@ -0,0 +1,84 @@
+package command
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/trugamr/bravia/api"
+)
+
+func init() {
+	rootCmd.AddCommand(pairCmd)
+
+	// Define flags for the pair command
+	pairCmd.Flags().StringP("nickname", "n", "Bravia CLI", "Name shown in the TV's list of registered devices")
+}
+
+var pairCmd = &cobra.Command{
+	Use:   "pair",
+	Short: "Pair with your TV using a PIN",
+	Long: `Registers this CLI with your TV as an alternative to a pre-shared key.
+The TV shows a PIN on screen, and the credential it returns is saved in the config file.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		nickname, err := cmd.Flags().GetString("nickname")
+		if err != nil {
+			exitWithError(err)
+		}
+
+		// Keep the client ID stable so the TV recognizes us when registering again
+		if cfg.ClientID == "" {
+			cfg.ClientID = newClientID()
+		}
+		reg := registration()
+		reg.Nickname = nickname
+
+		cookie, err := api.Pair(cmd.Context(), client, reg, promptPIN)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		cfg.AuthCookie = cookie.Value
+		err = cfg.Write(map[string]interface{}{
+			"client_id":   cfg.ClientID,
+			"auth_cookie": cfg.AuthCookie,
+		})
+		if err != nil {
+			exitWithError(err)
+		}
+
+		fmt.Println("Paired successfully")
+	},
+}
+
+// registration returns the registration used to pair with the TV
+func registration() api.Registration {
+	return api.Registration{
+		ClientID: cfg.ClientID,
+		Nickname: "Bravia CLI",
+	}
+}
+
+// newClientID returns a random client ID
+func newClientID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return "bravia:" + hex.EncodeToString(b)
+}
+
+// promptPIN asks the user for the PIN shown on the TV
+func promptPIN(ctx context.Context) (string, error) {
+	fmt.Print("Enter the PIN shown on your TV: ")
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("failed to read PIN: %w", err)
+	}
+
+	return strings.TrimSpace(line), nil
+}
@@ -0,0 +1,88 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/trugamr/bravia/api"
+)
+
+func init() {
+	pictureCmd.AddCommand(pictureGetCmd, pictureSetCmd)
+
+	rootCmd.AddCommand(pictureCmd)
+
+	// Define flags for the picture get command
+	addOutputFlag(pictureGetCmd)
+}
+
+// pictureTargets lists the picture quality settings offered for shell completion
+var pictureTargets = []string{
+	api.PictureMode, api.PictureBrightness, api.PictureContrast, api.PictureColor,
+	api.PictureSharpness, api.PictureColorTemperature, api.PictureMotionflow, api.PictureHDRMode,
+}
+
+var pictureCmd = &cobra.Command{
+	Use:   "picture",
+	Short: "Show and change picture quality settings",
+	Long: `Allows you to show and change the picture quality settings of your TV, such as the
+picture mode, brightness, contrast, color, sharpness, color temperature, motionflow and HDR mode.`,
+}
+
+var pictureGetCmd = &cobra.Command{
+	Use:       "get [target]",
+	Short:     "Show picture quality settings and the values they accept",
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: pictureTargets,
+	Run: func(cmd *cobra.Command, args []string) {
+		output := outputFormat(cmd)
+
+		target := ""
+		if len(args) > 0 {
+			target = args[0]
+		}
+
+		result, _, err := client.Video.GetPictureQualitySettings(cmd.Context(), target)
+		if err != nil {
+			exitWithError(err)
+		}
+		settings := result.Result[0]
+
+		if output == outputJSON {
+			printJSON(settings)
+			return
+		}
+
+		table := newTable()
+		fmt.Fprintln(table, "TARGET\tVALUE\tACCEPTED")
+		for _, setting := range settings {
+			accepted := make([]string, len(setting.Candidate))
+			for i, candidate := range setting.Candidate {
+				accepted[i] = candidate.String()
+			}
+			if !setting.IsAvailable {
+				accepted = []string{"(unavailable)"}
+			}
+			fmt.Fprintf(table, "%s\t%s\t%s\n", setting.Target, setting.CurrentValue, strings.Join(accepted, ", "))
+		}
+		table.Flush()
+	},
+}
+
+var pictureSetCmd = &cobra.Command{
+	Use:   "set <target> <value>",
+	Short: "Change a picture quality setting",
+	Long: `Changes a picture quality setting, such as "bravia picture set brightness 30" or
+"bravia picture set pictureMode cinema". The value is checked against the values the TV accepts,
+run "bravia picture get" to list them.`,
+	Args:      cobra.ExactArgs(2),
+	ValidArgs: pictureTargets,
+	Run: func(cmd *cobra.Command, args []string) {
+		value, err := client.Video.SetPictureQuality(cmd.Context(), args[0], args[1])
+		if err != nil {
+			exitWithError(err)
+		}
+		fmt.Printf("%s set to %s\n", args[0], value)
+	},
+}
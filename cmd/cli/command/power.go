@@ -1,16 +1,25 @@
 package command
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/trugamr/bravia/api"
+	"github.com/trugamr/bravia/api/wol"
 )
 
 func init() {
 	powerCmd.AddCommand(powerOnCmd, powerOffCmd, powerStatusCmd)
 
 	rootCmd.AddCommand(powerCmd)
+
+	// Define flags for the power commands
+	powerOnCmd.Flags().Bool("wol", false, "Send a Wake-on-LAN packet without trying the API first")
+	for _, cmd := range []*cobra.Command{powerOnCmd, powerOffCmd} {
+		cmd.Flags().Bool("learn-mac", false, "Save the MAC address of the TV into the config file if none is configured")
+	}
 }
 
 var powerCmd = &cobra.Command{
@@ -22,11 +31,35 @@ var powerCmd = &cobra.Command{
 var powerOnCmd = &cobra.Command{
 	Use:   "on",
 	Short: "Turn on the TV",
+	Long: `Turns on the TV through the API. When the TV doesn't answer because its network stack
+is asleep, a Wake-on-LAN packet is sent to the MAC address from the config instead.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		_, _, err := client.System.SetPowerStatus(true)
+		forceWOL, err := cmd.Flags().GetBool("wol")
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-			os.Exit(1)
+			exitWithError(err)
+		}
+
+		opts := []api.PowerOnOption{api.WithWakeOnLAN(cfg.MACAddress, wolSettings().Options()...)}
+		if forceWOL {
+			opts = append(opts, api.WithForceWakeOnLAN())
+		}
+		if shouldLearnMAC(cmd) {
+			opts = append(opts, api.WithMACLearning(saveMACAddress))
+		}
+
+		woke, err := client.System.PowerOn(cmd.Context(), opts...)
+		if errors.Is(err, api.ErrNoMACAddress) {
+			exitWithError(errors.New("TV is not responding and no MAC address is configured for Wake-on-LAN, set mac_address in the config or use --mac"))
+		}
+		if err != nil {
+			exitWithError(err)
+		}
+
+		if woke {
+			if !forceWOL {
+				fmt.Fprintln(os.Stderr, "TV is not responding, fell back to Wake-on-LAN")
+			}
+			fmt.Fprintf(os.Stderr, "Sent Wake-on-LAN packet to %s\n", cfg.MACAddress)
 		}
 	},
 }
@@ -35,10 +68,17 @@ var powerOffCmd = &cobra.Command{
 	Use:   "off",
 	Short: "Turn off the TV",
 	Run: func(cmd *cobra.Command, args []string) {
-		_, _, err := client.System.SetPowerStatus(false)
+		// Learn the MAC address while the TV is still reachable, it's needed to turn it on again.
+		// Failures are ignored, it's best effort.
+		if shouldLearnMAC(cmd) {
+			if mac, err := client.System.MACAddress(cmd.Context()); err == nil {
+				saveMACAddress(mac)
+			}
+		}
+
+		_, _, err := client.System.SetPowerStatus(cmd.Context(), false)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-			os.Exit(1)
+			exitWithError(err)
 		}
 	},
 }
@@ -47,10 +87,9 @@ var powerStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Check the power status of the TV",
 	Run: func(cmd *cobra.Command, args []string) {
-		result, _, err := client.System.GetPowerStatus()
+		result, _, err := client.System.GetPowerStatus(cmd.Context())
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-			os.Exit(1)
+			exitWithError(err)
 		}
 
 		status := result.Result[0].Status
@@ -58,3 +97,26 @@ var powerStatusCmd = &cobra.Command{
 		fmt.Println(status)
 	},
 }
+
+// wolSettings returns the Wake-on-LAN settings from the config
+func wolSettings() wol.Settings {
+	return wol.Settings{Broadcast: cfg.WOLBroadcast, Port: cfg.WOLPort, Repeat: cfg.WOLRepeat}
+}
+
+// shouldLearnMAC reports whether to save the MAC address of the TV into the config file, which is
+// only done when asked to with the learn_mac setting or --learn-mac, and if none is configured yet
+func shouldLearnMAC(cmd *cobra.Command) bool {
+	learn, err := cmd.Flags().GetBool("learn-mac")
+	if err != nil {
+		exitWithError(err)
+	}
+	return cfg.MACAddress == "" && (learn || cfg.LearnMAC)
+}
+
+// saveMACAddress saves the MAC address of the TV into the config file, so it can be woken up later
+func saveMACAddress(mac string) {
+	cfg.MACAddress = mac
+	if err := cfg.Write(map[string]interface{}{"mac_address": mac}); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save MAC address: %s\n", err)
+	}
+}
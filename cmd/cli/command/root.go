@@ -1,12 +1,18 @@
 package command
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
+	"net/http"
 	"net/url"
 	"os"
+	"os/signal"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/trugamr/bravia/api"
+	"github.com/trugamr/bravia/api/replay"
 	"github.com/trugamr/bravia/cmd/cli/config"
 )
 
@@ -21,24 +27,108 @@ func init() {
 	// Add config flags to root command
 	cfg.AddFlags(rootCmd)
 
+	// Define logging flags, available to every command
+	rootCmd.PersistentFlags().Bool("debug", false, "Log a summary of each call to the TV")
+	rootCmd.PersistentFlags().Bool("trace", false, "Log full requests and responses exchanged with the TV")
+
+	// Define record and replay flags, to share a TV's responses and work offline
+	rootCmd.PersistentFlags().String("record", "", "Record the exchanges with the TV to this cassette file")
+	rootCmd.PersistentFlags().String("replay", "", "Answer requests from this cassette file instead of the TV")
+	rootCmd.MarkFlagsMutuallyExclusive("record", "replay")
+
 	// Initialize configuration before any command runs
 	cobra.OnInitialize(initConfig)
 }
 
 func initConfig() {
 	if err := cfg.Load(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		os.Exit(1)
+		exitWithError(err)
 	}
 
 	// Create client using config values
 	baseURL, err := url.Parse(cfg.BaseURL)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		os.Exit(1)
+		exitWithError(err)
 	}
 
-	client = api.NewClient(baseURL).WithAuthPSK(cfg.PSK)
+	opts, err := clientOptions()
+	if err != nil {
+		exitWithError(err)
+	}
+	client = api.NewClient(baseURL, opts...)
+
+	// Use the credential from pairing, registering again when it expires
+	if cfg.AuthCookie != "" {
+		client = client.WithPairing(registration(), cfg.AuthCookie, func(cookie string) {
+			if err := cfg.Write(map[string]interface{}{"auth_cookie": cookie}); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to save renewed auth cookie: %s\n", err)
+			}
+		})
+	}
+}
+
+// clientOptions returns the API client options from the config and flags
+func clientOptions() ([]api.Option, error) {
+	opts := []api.Option{
+		api.WithAuthPSK(cfg.PSK),
+		api.WithUserAgent("bravia-cli"),
+	}
+	if logger := newLogger(); logger != nil {
+		opts = append(opts, api.WithLogger(logger))
+	}
+	if cfg.Retries > 0 {
+		opts = append(opts, api.WithRetry(api.RetryPolicy{
+			Attempts:   cfg.Retries + 1,
+			MinBackoff: cfg.RetryBackoff,
+			MaxBackoff: 5 * time.Second,
+		}))
+	}
+	if transport, err := replayTransport(); err != nil {
+		return nil, err
+	} else if transport != nil {
+		opts = append(opts, api.WithHTTPClient(&http.Client{Transport: transport}))
+	}
+	if cfg.MaxConcurrent > 0 {
+		opts = append(opts, api.WithConcurrencyLimit(cfg.MaxConcurrent))
+	}
+	if cfg.BreakerThreshold > 0 {
+		opts = append(opts, api.WithCircuitBreaker(api.CircuitBreaker{
+			Threshold: cfg.BreakerThreshold,
+			Cooldown:  cfg.BreakerCooldown,
+		}))
+	}
+	return opts, nil
+}
+
+// replayTransport returns the transport recording to or replaying from the cassette given by
+// --record or --replay, or nil if neither is set
+func replayTransport() (http.RoundTripper, error) {
+	if path, _ := rootCmd.PersistentFlags().GetString("record"); path != "" {
+		return replay.NewRecorder(path, http.DefaultTransport)
+	}
+	if path, _ := rootCmd.PersistentFlags().GetString("replay"); path != "" {
+		return replay.Load(path)
+	}
+	return nil, nil
+}
+
+// newLogger returns the logger for calls to the TV, or nil if neither --debug nor --trace is set
+func newLogger() *slog.Logger {
+	level := slog.LevelInfo
+	if debug, _ := rootCmd.PersistentFlags().GetBool("debug"); debug {
+		level = slog.LevelDebug
+	}
+	if trace, _ := rootCmd.PersistentFlags().GetBool("trace"); trace {
+		level = api.LevelTrace
+	}
+	if level == slog.LevelInfo {
+		return nil
+	}
+
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: api.ReplaceLevelNames,
+	}))
 }
 
 var rootCmd = &cobra.Command{
@@ -51,8 +141,11 @@ through simple commands.`,
 
 // ExecuteRoot is the entrypoint for the CLI
 func ExecuteRoot() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-		os.Exit(1)
+	// Cancel in-flight TV requests when the user interrupts the command
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		exitWithError(err)
 	}
 }
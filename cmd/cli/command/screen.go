@@ -0,0 +1,53 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	screenCmd.AddCommand(screenOffCmd, screenOnCmd, screenStatusCmd)
+
+	rootCmd.AddCommand(screenCmd)
+}
+
+var screenCmd = &cobra.Command{
+	Use:   "screen",
+	Short: "Turn the picture off while the sound keeps playing",
+	Long: `Allows you to blank the screen of the TV while it keeps playing, for example to listen
+to music from a streaming app. Unlike "bravia power off", the TV doesn't go into standby.`,
+}
+
+var screenOffCmd = &cobra.Command{
+	Use:   "off",
+	Short: "Turn the picture off, keeping the sound",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := client.System.ScreenOff(cmd.Context()); err != nil {
+			exitWithError(err)
+		}
+	},
+}
+
+var screenOnCmd = &cobra.Command{
+	Use:   "on",
+	Short: "Turn the picture back on",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := client.System.ScreenOn(cmd.Context()); err != nil {
+			exitWithError(err)
+		}
+	},
+}
+
+var screenStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Check whether the picture is on or off",
+	Run: func(cmd *cobra.Command, args []string) {
+		off, err := client.System.IsScreenOff(cmd.Context())
+		if err != nil {
+			exitWithError(err)
+		}
+
+		fmt.Println(onOff(!off))
+	},
+}
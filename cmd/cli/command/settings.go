@@ -0,0 +1,133 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/trugamr/bravia/api"
+)
+
+func init() {
+	settingsCmd.AddCommand(settingsPowerSavingCmd, settingsLEDCmd, settingsWolCmd)
+
+	rootCmd.AddCommand(settingsCmd)
+}
+
+var settingsCmd = &cobra.Command{
+	Use:   "settings",
+	Short: "Show and change settings of your TV",
+	Long:  `Allows you to show and change the power saving mode, LED indicator and Wake-on-LAN settings of your TV.`,
+}
+
+var settingsPowerSavingCmd = &cobra.Command{
+	Use:   "power-saving [mode]",
+	Short: "Show or set the power saving mode",
+	Long: fmt.Sprintf(`Shows the power saving mode of the TV, or sets it to the given mode.
+Accepted modes are %s, although not every model supports all of them.
+pictureOff turns the screen off while keeping the sound.`, joinModes(api.PowerSavingModes)),
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: stringModes(api.PowerSavingModes),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			result, _, err := client.System.GetPowerSavingMode(cmd.Context())
+			if err != nil {
+				exitWithError(err)
+			}
+			fmt.Println(result.Result[0].Mode)
+			return
+		}
+
+		mode, err := client.System.SetPowerSaving(cmd.Context(), args[0])
+		if err != nil {
+			exitWithError(err)
+		}
+		fmt.Printf("Power saving mode set to %s\n", mode)
+	},
+}
+
+var settingsLEDCmd = &cobra.Command{
+	Use:   "led [mode]",
+	Short: "Show or set the LED indicator mode",
+	Long: fmt.Sprintf(`Shows the LED indicator mode of the TV, or sets it to the given mode.
+Accepted modes are %s, although not every model supports all of them.`, joinModes(api.LEDIndicatorModes)),
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: stringModes(api.LEDIndicatorModes),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			result, _, err := client.System.GetLEDIndicatorStatus(cmd.Context())
+			if err != nil {
+				exitWithError(err)
+			}
+			status := result.Result[0]
+			lit := "off"
+			if status.Status == "true" {
+				lit = "on"
+			}
+			fmt.Printf("%s (LED %s)\n", status.Mode, lit)
+			return
+		}
+
+		mode, err := client.System.SetLEDIndicator(cmd.Context(), args[0])
+		if err != nil {
+			exitWithError(err)
+		}
+		fmt.Printf("LED indicator mode set to %s\n", mode)
+	},
+}
+
+var settingsWolCmd = &cobra.Command{
+	Use:   "wol [on|off]",
+	Short: "Show or set whether the TV wakes up on Wake-on-LAN",
+	Long: `Shows whether the TV wakes up on Wake-on-LAN magic packets, or turns it on or off.
+It must be on for "bravia power on" to wake the TV from deep standby.`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{"on", "off"},
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			result, _, err := client.System.GetWolMode(cmd.Context())
+			if err != nil {
+				exitWithError(err)
+			}
+			fmt.Println(onOff(result.Result[0].Enabled))
+			return
+		}
+
+		var enabled bool
+		switch strings.ToLower(args[0]) {
+		case "on":
+			enabled = true
+		case "off":
+			enabled = false
+		default:
+			exitWithError(fmt.Errorf("invalid Wake-on-LAN mode %q, expected on or off", args[0]))
+		}
+
+		if _, _, err := client.System.SetWolMode(cmd.Context(), enabled); err != nil {
+			exitWithError(err)
+		}
+		fmt.Printf("Wake-on-LAN turned %s\n", onOff(enabled))
+	},
+}
+
+// onOff returns "on" or "off" for b
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+// stringModes returns modes as strings, for shell completion
+func stringModes[M ~string](modes []M) []string {
+	names := make([]string, len(modes))
+	for i, mode := range modes {
+		names[i] = string(mode)
+	}
+	return names
+}
+
+// joinModes returns modes as a comma separated list, for help texts
+func joinModes[M ~string](modes []M) string {
+	return strings.Join(stringModes(modes), ", ")
+}
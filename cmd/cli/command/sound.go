@@ -0,0 +1,155 @@
+package command
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/trugamr/bravia/api"
+)
+
+func init() {
+	soundSpeakerCmd.AddCommand(soundSpeakerGetCmd, soundSpeakerSetCmd)
+	soundCmd.AddCommand(soundGetCmd, soundSetCmd, soundOutputCmd, soundSpeakerCmd)
+
+	rootCmd.AddCommand(soundCmd)
+
+	// Define flags for the get commands
+	addOutputFlag(soundGetCmd)
+	addOutputFlag(soundSpeakerGetCmd)
+}
+
+var soundCmd = &cobra.Command{
+	Use:   "sound",
+	Short: "Show and change sound and speaker settings",
+	Long: `Allows you to show and change the sound settings of your TV, such as where the sound is
+played, and its speaker settings, such as the TV position and subwoofer level.`,
+}
+
+var soundGetCmd = &cobra.Command{
+	Use:   "get [target]",
+	Short: "Show sound settings and the values they accept",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		result, _, err := client.Audio.GetSoundSettings(cmd.Context(), optionalArg(args))
+		if err != nil {
+			exitWithError(err)
+		}
+		printAudioSettings(cmd, result.Result[0])
+	},
+}
+
+var soundSetCmd = &cobra.Command{
+	Use:   "set <target> <value>",
+	Short: "Change a sound setting",
+	Long: `Changes a sound setting, such as "bravia sound set outputTerminal hdmi". The value is
+checked against the values the TV accepts, run "bravia sound get" to list them.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		value, err := client.Audio.SetSound(cmd.Context(), args[0], args[1])
+		if err != nil {
+			exitWithError(err)
+		}
+		fmt.Printf("%s set to %s\n", args[0], value)
+	},
+}
+
+var soundOutputCmd = &cobra.Command{
+	Use:   "output [terminal]",
+	Short: "Show or set where the sound is played",
+	Long: fmt.Sprintf(`Shows where the sound is played, or switches it to the given output terminal:
+%s for the TV speakers, %s for a soundbar on HDMI ARC, %s for both,
+or %s for an audio system. Not every model supports all of them.`,
+		api.OutputTerminalSpeaker, api.OutputTerminalHDMI, api.OutputTerminalSpeakerHDMI, api.OutputTerminalAudioSystem),
+	Args: cobra.MaximumNArgs(1),
+	ValidArgs: []string{
+		api.OutputTerminalSpeaker, api.OutputTerminalHDMI, api.OutputTerminalSpeakerHDMI, api.OutputTerminalAudioSystem,
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			result, _, err := client.Audio.GetSoundSettings(cmd.Context(), api.SoundOutputTerminal)
+			if err != nil {
+				exitWithError(err)
+			}
+			for _, setting := range result.Result[0] {
+				fmt.Println(setting.CurrentValue)
+			}
+			return
+		}
+
+		value, err := client.Audio.SetSound(cmd.Context(), api.SoundOutputTerminal, args[0])
+		if err != nil {
+			exitWithError(err)
+		}
+		fmt.Printf("Sound output set to %s\n", value)
+	},
+}
+
+var soundSpeakerCmd = &cobra.Command{
+	Use:   "speaker",
+	Short: "Show and change speaker settings",
+}
+
+var soundSpeakerGetCmd = &cobra.Command{
+	Use:   "get [target]",
+	Short: "Show speaker settings and the values they accept",
+	Args:  cobra.MaximumNArgs(1),
+	ValidArgs: []string{
+		api.SpeakerTVPosition, api.SpeakerSubwooferLevel, api.SpeakerSoundField,
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		result, _, err := client.Audio.GetSpeakerSettings(cmd.Context(), optionalArg(args))
+		if err != nil {
+			exitWithError(err)
+		}
+		printAudioSettings(cmd, result.Result[0])
+	},
+}
+
+var soundSpeakerSetCmd = &cobra.Command{
+	Use:   "set <target> <value>",
+	Short: "Change a speaker setting",
+	Long: `Changes a speaker setting, such as "bravia sound speaker set tvPosition wallMount". The value
+is checked against the values the TV accepts, run "bravia sound speaker get" to list them.`,
+	Args: cobra.ExactArgs(2),
+	ValidArgs: []string{
+		api.SpeakerTVPosition, api.SpeakerSubwooferLevel, api.SpeakerSoundField,
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		value, err := client.Audio.SetSpeaker(cmd.Context(), args[0], args[1])
+		if err != nil {
+			exitWithError(err)
+		}
+		fmt.Printf("%s set to %s\n", args[0], value)
+	},
+}
+
+// printAudioSettings prints sound or speaker settings in the format given by --output
+func printAudioSettings(cmd *cobra.Command, settings []api.AudioSetting) {
+	if outputFormat(cmd) == outputJSON {
+		printJSON(settings)
+		return
+	}
+
+	table := newTable()
+	fmt.Fprintln(table, "TARGET\tVALUE\tACCEPTED")
+	for _, setting := range settings {
+		accepted := make([]string, 0, len(setting.Candidate))
+		for _, candidate := range setting.Candidate {
+			if candidate.IsAvailable {
+				accepted = append(accepted, candidate.String())
+			}
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\n", setting.Target, setting.CurrentValue, cmp.Or(strings.Join(accepted, ", "), "-"))
+	}
+	table.Flush()
+}
+
+// optionalArg returns the first argument, or an empty string if there is none
+func optionalArg(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return args[0]
+}
@@ -1,13 +1,18 @@
 package command
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/trugamr/bravia/api"
 )
 
 func init() {
+	volumeCmd.AddCommand(volumeGetCmd, volumeFadeCmd)
+
 	// Add volume command to root command
 	rootCmd.AddCommand(volumeCmd)
 
@@ -17,28 +22,113 @@ func init() {
 
 	// Mark required flags
 	volumeCmd.MarkFlagRequired("level")
+
+	// Define flags for the volume get command
+	volumeGetCmd.Flags().StringP("target", "t", "", "Only show the target (e.g., speaker, headphone)")
+	addOutputFlag(volumeGetCmd)
+
+	// Define flags for the volume fade command
+	volumeFadeCmd.Flags().Int("to", 0, "Volume level to fade to")
+	volumeFadeCmd.Flags().Duration("over", 10*time.Second, "Time to spread the fade over (e.g., 30s, 2m)")
+	volumeFadeCmd.Flags().StringP("target", "t", "speaker", "Target to fade (e.g., speaker, headphone)")
+	volumeFadeCmd.MarkFlagRequired("to")
 }
 
 var volumeCmd = &cobra.Command{
 	Use:   "volume",
 	Short: "Control the volume of the TV",
-	Long:  `Allows setting the volume of the TV.`,
+	Long: `Allows setting the volume of the TV to a level, or changing it with +N or -N.
+Levels are kept between the minimum and maximum volume the TV reports for each target.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		level, err := cmd.Flags().GetString("level")
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-			os.Exit(1)
+			exitWithError(err)
+		}
+		target, err := cmd.Flags().GetString("target")
+		if err != nil {
+			exitWithError(err)
+		}
+
+		if err := client.Audio.SetVolume(cmd.Context(), target, level); err != nil {
+			exitWithError(err)
+		}
+	},
+}
+
+var volumeGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Show the volume of each target",
+	Run: func(cmd *cobra.Command, args []string) {
+		output := outputFormat(cmd)
+		target, err := cmd.Flags().GetString("target")
+		if err != nil {
+			exitWithError(err)
+		}
+
+		result, _, err := client.Audio.GetVolumeInformation(cmd.Context())
+		if err != nil {
+			exitWithError(err)
+		}
+
+		volumes := result.Result[0]
+		if target != "" {
+			volumes = nil
+			for _, info := range result.Result[0] {
+				if info.Target == target {
+					volumes = append(volumes, info)
+				}
+			}
+			if len(volumes) == 0 {
+				exitWithError(fmt.Errorf("volume target %q is not supported by this TV", target))
+			}
+		}
+
+		if output == outputJSON {
+			printJSON(volumes)
+			return
+		}
+
+		table := newTable()
+		fmt.Fprintln(table, "TARGET\tVOLUME\tMIN\tMAX\tMUTED")
+		for _, info := range volumes {
+			fmt.Fprintf(table, "%s\t%d\t%d\t%d\t%t\n", info.Target, info.Volume, info.MinVolume, info.MaxVolume, info.Mute)
+		}
+		table.Flush()
+	},
+}
+
+var volumeFadeCmd = &cobra.Command{
+	Use:   "fade",
+	Short: "Gradually change the volume",
+	Long: `Gradually changes the volume to a level over some time, such as a gentle fade-in
+with "bravia volume fade --to 25 --over 30s". Interrupting the command stops the fade
+at the current level.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		to, err := cmd.Flags().GetInt("to")
+		if err != nil {
+			exitWithError(err)
+		}
+		over, err := cmd.Flags().GetDuration("over")
+		if err != nil {
+			exitWithError(err)
 		}
 		target, err := cmd.Flags().GetString("target")
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-			os.Exit(1)
+			exitWithError(err)
 		}
 
-		_, _, err = client.Audio.SetAudioVolume(level, target)
+		volume := -1
+		err = client.Audio.RampVolume(cmd.Context(), target, to, over, api.WithRampProgress(func(v int) {
+			volume = v
+		}))
+		if errors.Is(err, context.Canceled) && volume >= 0 {
+			exitWithError(fmt.Errorf("fade stopped at %d: %w", volume, err))
+		}
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
-			os.Exit(1)
+			exitWithError(err)
+		}
+		if volume >= 0 {
+			fmt.Printf("Volume faded to %d\n", volume)
 		}
 	},
 }
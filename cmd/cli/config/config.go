@@ -1,22 +1,41 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	BaseURL string `mapstructure:"base_url"`
-	PSK     string `mapstructure:"psk"`
+	BaseURL    string `mapstructure:"base_url"`
+	PSK        string `mapstructure:"psk"`
+	AuthCookie string `mapstructure:"auth_cookie"`
+	ClientID   string `mapstructure:"client_id"`
+
+	MACAddress   string `mapstructure:"mac_address"`
+	WOLBroadcast string `mapstructure:"wol_broadcast"`
+	WOLPort      int    `mapstructure:"wol_port"`
+	WOLRepeat    int    `mapstructure:"wol_repeat"`
+	LearnMAC     bool   `mapstructure:"learn_mac"` // Save the MAC address of the TV when none is configured
+
+	Retries          int           `mapstructure:"retries"`
+	RetryBackoff     time.Duration `mapstructure:"retry_backoff"`
+	MaxConcurrent    int           `mapstructure:"max_concurrent"`
+	BreakerThreshold int           `mapstructure:"breaker_threshold"`
+	BreakerCooldown  time.Duration `mapstructure:"breaker_cooldown"`
 }
 
 func New() *Config {
-	return &Config{}
+	return &Config{
+		RetryBackoff: 200 * time.Millisecond, // Default backoff before the first retry, when retries are enabled
+	}
 }
 
 // Load initializes Viper, loads the configuration, and populates the Config struct
@@ -43,6 +62,18 @@ func (c *Config) Load() error {
 	// Check: https://github.com/spf13/viper/issues/188#issuecomment-255519149
 	viper.BindEnv("BASE_URL")
 	viper.BindEnv("PSK")
+	viper.BindEnv("AUTH_COOKIE")
+	viper.BindEnv("CLIENT_ID")
+	viper.BindEnv("MAC_ADDRESS")
+	viper.BindEnv("WOL_BROADCAST")
+	viper.BindEnv("WOL_PORT")
+	viper.BindEnv("WOL_REPEAT")
+	viper.BindEnv("LEARN_MAC")
+	viper.BindEnv("RETRIES")
+	viper.BindEnv("RETRY_BACKOFF")
+	viper.BindEnv("MAX_CONCURRENT")
+	viper.BindEnv("BREAKER_THRESHOLD")
+	viper.BindEnv("BREAKER_COOLDOWN")
 
 	// Attempt to read the config file, ignore error if not found
 	if err := viper.ReadInConfig(); err != nil {
@@ -59,13 +90,49 @@ func (c *Config) Load() error {
 	return nil
 }
 
+// Write stores the given settings in the config file that was loaded,
+// or in $HOME/.bravia/config.yaml if no config file was found
+func (c *Config) Write(settings map[string]interface{}) error {
+	path := viper.ConfigFileUsed()
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to get home directory: %w", err)
+		}
+
+		path = filepath.Join(home, ".bravia", "config.yaml")
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return fmt.Errorf("failed to create config directory: %w", err)
+		}
+	}
+
+	// Use a separate instance so flags and environment variables don't leak into the file
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error loading config file: %w", err)
+	}
+
+	for key, value := range settings {
+		v.Set(key, value)
+	}
+
+	if err := v.WriteConfigAs(path); err != nil {
+		return fmt.Errorf("error writing config file: %w", err)
+	}
+
+	return nil
+}
+
 // AddFlags adds flags to a Cobra command and binds them to Viper
 func (c *Config) AddFlags(cmd *cobra.Command) {
 	// Define flags
 	cmd.PersistentFlags().StringVar(&c.BaseURL, "base-url", "", "Base URL for the API")
 	cmd.PersistentFlags().StringVar(&c.PSK, "psk", "", "Pre-shared key for the API")
+	cmd.PersistentFlags().StringVar(&c.MACAddress, "mac", "", "MAC address of the TV, used to wake it up with Wake-on-LAN")
 
 	// Bind flags to Viper
-	viper.BindPFlag("base_url", cmd.Flags().Lookup("base-url"))
-	viper.BindPFlag("psk", cmd.Flags().Lookup("psk"))
+	viper.BindPFlag("base_url", cmd.PersistentFlags().Lookup("base-url"))
+	viper.BindPFlag("psk", cmd.PersistentFlags().Lookup("psk"))
+	viper.BindPFlag("mac_address", cmd.PersistentFlags().Lookup("mac"))
 }
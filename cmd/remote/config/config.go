@@ -1,23 +1,49 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
+	"github.com/trugamr/bravia/api"
 )
 
 type Config struct {
-	BaseURL string `mapstructure:"base_url"`
-	PSK     string `mapstructure:"psk"`
-	Port    string `mapstructure:"port"`
+	BaseURL    string `mapstructure:"base_url"`
+	PSK        string `mapstructure:"psk"`
+	AuthCookie string `mapstructure:"auth_cookie"`
+	ClientID   string `mapstructure:"client_id"`
+	Port       string `mapstructure:"port"`
+	LogLevel   string `mapstructure:"log_level"`
+	Record     string `mapstructure:"record"` // Cassette file to record the exchanges with the TV to
+	Replay     string `mapstructure:"replay"` // Cassette file to answer requests from instead of the TV
+
+	MACAddress   string `mapstructure:"mac_address"`
+	WOLBroadcast string `mapstructure:"wol_broadcast"`
+	WOLPort      int    `mapstructure:"wol_port"`
+	WOLRepeat    int    `mapstructure:"wol_repeat"`
+
+	Retries          int           `mapstructure:"retries"`
+	RetryBackoff     time.Duration `mapstructure:"retry_backoff"`
+	MaxConcurrent    int           `mapstructure:"max_concurrent"`
+	BreakerThreshold int           `mapstructure:"breaker_threshold"`
+	BreakerCooldown  time.Duration `mapstructure:"breaker_cooldown"`
 }
 
 func New() *Config {
 	return &Config{
-		Port: "8080", // Default port
+		Port:             "8080",                 // Default port
+		LogLevel:         "info",                 // Default log level, "debug" or "trace" to log calls to the TV
+		RetryBackoff:     200 * time.Millisecond, // Default backoff before the first retry, when retries are enabled
+		MaxConcurrent:    4,                      // Default requests in flight to the TV
+		BreakerThreshold: 5,                      // Default consecutive failures before failing fast
+		BreakerCooldown:  10 * time.Second,       // Default time to fail fast before trying again
 	}
 }
 
@@ -44,7 +70,21 @@ func (c *Config) Load() error {
 	// Manually bind environment variables
 	viper.BindEnv("BASE_URL")
 	viper.BindEnv("PSK")
+	viper.BindEnv("AUTH_COOKIE")
+	viper.BindEnv("CLIENT_ID")
 	viper.BindEnv("PORT")
+	viper.BindEnv("LOG_LEVEL")
+	viper.BindEnv("RECORD")
+	viper.BindEnv("REPLAY")
+	viper.BindEnv("MAC_ADDRESS")
+	viper.BindEnv("WOL_BROADCAST")
+	viper.BindEnv("WOL_PORT")
+	viper.BindEnv("WOL_REPEAT")
+	viper.BindEnv("RETRIES")
+	viper.BindEnv("RETRY_BACKOFF")
+	viper.BindEnv("MAX_CONCURRENT")
+	viper.BindEnv("BREAKER_THRESHOLD")
+	viper.BindEnv("BREAKER_COOLDOWN")
 
 	// Attempt to read the config file, ignore error if not found
 	if err := viper.ReadInConfig(); err != nil {
@@ -59,12 +99,75 @@ func (c *Config) Load() error {
 	}
 
 	// Validate required fields
+	if c.Record != "" && c.Replay != "" {
+		return fmt.Errorf("record and replay can't be used together")
+	}
+	if c.Replay != "" {
+		// Requests are answered from the cassette, the TV and its credentials aren't needed
+		return c.validateLogLevel()
+	}
 	if c.BaseURL == "" {
 		return fmt.Errorf("base_url is required (set via config file, --base-url flag, or BRAVIA_BASE_URL env var)")
 	}
-	if c.PSK == "" {
-		return fmt.Errorf("psk is required (set via config file, --psk flag, or BRAVIA_PSK env var)")
+	if c.PSK == "" && c.AuthCookie == "" {
+		return fmt.Errorf("psk or auth_cookie is required (set psk via config file or BRAVIA_PSK env var, or run `bravia pair`)")
+	}
+	return c.validateLogLevel()
+}
+
+// Write stores the given settings in the config file that was loaded,
+// or in $HOME/.bravia/config.yaml if no config file was found
+func (c *Config) Write(settings map[string]interface{}) error {
+	path := viper.ConfigFileUsed()
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to get home directory: %w", err)
+		}
+
+		path = filepath.Join(home, ".bravia", "config.yaml")
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return fmt.Errorf("failed to create config directory: %w", err)
+		}
+	}
+
+	// Use a separate instance so environment variables don't leak into the file
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error loading config file: %w", err)
+	}
+
+	for key, value := range settings {
+		v.Set(key, value)
+	}
+
+	if err := v.WriteConfigAs(path); err != nil {
+		return fmt.Errorf("error writing config file: %w", err)
 	}
 
 	return nil
 }
+
+// validateLogLevel checks the log_level setting
+func (c *Config) validateLogLevel() error {
+	if _, err := c.Level(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Level returns the log level from the log_level setting, which also accepts "trace"
+// to log the full requests and responses exchanged with the TV
+func (c *Config) Level() (slog.Level, error) {
+	if strings.EqualFold(c.LogLevel, "trace") {
+		return api.LevelTrace, nil
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		return 0, fmt.Errorf("invalid log_level %q, expected trace, debug, info, warn or error", c.LogLevel)
+	}
+	return level, nil
+}
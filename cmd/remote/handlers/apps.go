@@ -17,9 +17,9 @@ func (h *Handler) AppsListHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, _, err := h.Client.AppControl.GetApplicationList()
+	result, _, err := h.Client.AppControl.GetApplicationList(r.Context())
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		respondWithAPIError(w, err)
 		return
 	}
 
@@ -51,11 +51,16 @@ func (h *Handler) AppsOpenHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, _, err := h.Client.AppControl.SetActiveApp(req.URI, nil)
+	_, _, err := h.Client.AppControl.SetActiveApp(r.Context(), req.URI, nil)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		respondWithAPIError(w, err)
 		return
 	}
 
+	// The TV doesn't tell which app is in the foreground, remember it for the state updates
+	h.mu.Lock()
+	h.app = req.URI
+	h.mu.Unlock()
+
 	respondWithSuccess(w, map[string]string{"uri": req.URI})
 }
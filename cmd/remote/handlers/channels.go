@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/trugamr/bravia/api"
+)
+
+// ChannelTuneRequest represents the request body for tuning to a channel, given either its URI
+// or a number or name to look up
+type ChannelTuneRequest struct {
+	URI     string `json:"uri,omitempty"`     // URI of the channel, as listed by /api/channels
+	Channel string `json:"channel,omitempty"` // Channel number or name, names are matched fuzzily
+}
+
+// ChannelsListHandler lists the broadcast channels of the TV, read once and kept in memory.
+// The channels are read again with ?refresh=true, e.g. after a channel scan.
+func (h *Handler) ChannelsListHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	index, err := h.channelIndex(r.Context(), r.URL.Query().Get("refresh") == "true")
+	if err != nil {
+		respondWithAPIError(w, err)
+		return
+	}
+
+	respondWithSuccess(w, index.Channels)
+}
+
+// ChannelsTuneHandler tunes to a channel by URI, number or name
+func (h *Handler) ChannelsTuneHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req ChannelTuneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	var channel api.Channel
+	switch {
+	case req.URI != "":
+		// Numbers can repeat across sources such as tv:dvbt and tv:dvbc, URIs can't
+		channel = h.channelByURI(req.URI)
+	case req.Channel != "":
+		index, err := h.channelIndex(r.Context(), false)
+		if err != nil {
+			respondWithAPIError(w, err)
+			return
+		}
+
+		channel, err = index.Find(req.Channel)
+		if err != nil {
+			respondWithAPIError(w, err)
+			return
+		}
+	default:
+		respondWithError(w, http.StatusBadRequest, "uri or channel is required")
+		return
+	}
+
+	if _, _, err := h.Client.AVContent.SetPlayContent(r.Context(), channel.URI); err != nil {
+		respondWithAPIError(w, err)
+		return
+	}
+
+	respondWithSuccess(w, channel)
+}
+
+// channelByURI returns the channel with the URI from the channels kept in memory, or a channel
+// with just the URI if they weren't read yet
+func (h *Handler) channelByURI(uri string) api.Channel {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.channels != nil {
+		for _, channel := range h.channels.Channels {
+			if channel.URI == uri {
+				return channel
+			}
+		}
+	}
+	return api.Channel{URI: uri}
+}
+
+// channelIndex returns the channels kept in memory, reading them from the TV the first time
+// or when refresh is set
+func (h *Handler) channelIndex(ctx context.Context, refresh bool) (*api.ChannelIndex, error) {
+	h.mu.Lock()
+	index := h.channels
+	h.mu.Unlock()
+
+	if index != nil && !refresh {
+		return index, nil
+	}
+
+	index, err := h.Client.AVContent.NewChannelIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	h.mu.Lock()
+	h.channels = index
+	h.mu.Unlock()
+	return index, nil
+}
@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/trugamr/bravia/api"
+)
+
+// maxFadeDuration bounds how long a fade started from the remote may take
+const maxFadeDuration = 30 * time.Minute
+
+// VolumeFadeRequest represents the request body for fading the volume
+type VolumeFadeRequest struct {
+	To     *int   `json:"to"`               // Required, the volume to fade to
+	Over   string `json:"over"`             // Duration such as "30s", defaults to 10s
+	Target string `json:"target,omitempty"` // Defaults to speaker
+}
+
+// FadeProgress is sent over SSE while a fade is running
+type FadeProgress struct {
+	Type   string `json:"type"` // Always "fade"
+	Target string `json:"target"`
+	To     int    `json:"to"`
+	Volume int    `json:"volume"`
+	Done   bool   `json:"done"`
+	Error  string `json:"error,omitempty"`
+}
+
+// broadcaster sends events to every SSE client subscribed to it
+type broadcaster struct {
+	mu          sync.Mutex
+	subscribers map[chan interface{}]struct{}
+}
+
+// subscribe returns a channel receiving the published events, and a function to unsubscribe
+func (b *broadcaster) subscribe() (<-chan interface{}, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.subscribers == nil {
+		b.subscribers = make(map[chan interface{}]struct{})
+	}
+	events := make(chan interface{}, 16)
+	b.subscribers[events] = struct{}{}
+
+	return events, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subscribers, events)
+	}
+}
+
+// publish sends event to every subscriber, dropping it for those that are too slow
+func (b *broadcaster) publish(event interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for events := range b.subscribers {
+		select {
+		case events <- event:
+		default:
+		}
+	}
+}
+
+// VolumeFadeHandler starts fading the volume on POST, replacing any running fade,
+// and stops the running fade on DELETE. Progress is reported over SSE.
+func (h *Handler) VolumeFadeHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		var req VolumeFadeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		if req.To == nil {
+			respondWithError(w, http.StatusBadRequest, "to is required")
+			return
+		}
+
+		over := 10 * time.Second
+		if req.Over != "" {
+			var err error
+			if over, err = time.ParseDuration(req.Over); err != nil || over < 0 || over > maxFadeDuration {
+				respondWithError(w, http.StatusBadRequest, "over must be a duration between 0s and 30m")
+				return
+			}
+		}
+		target := req.Target
+		if target == "" {
+			target = "speaker"
+		}
+
+		// Check the target before responding, the fade itself runs after the response
+		if err := h.checkVolumeTarget(r.Context(), target); err != nil {
+			if errors.Is(err, errUnknownTarget) {
+				respondWithError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			respondWithAPIError(w, err)
+			return
+		}
+
+		h.startFade(target, *req.To, over)
+		respondWithSuccess(w, map[string]interface{}{"status": "fading", "target": target, "to": *req.To})
+	case http.MethodDelete:
+		stopped := h.stopFade()
+		respondWithSuccess(w, map[string]bool{"stopped": stopped})
+	default:
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// errUnknownTarget is returned by checkVolumeTarget for targets the TV has no volume for
+var errUnknownTarget = errors.New("unknown volume target")
+
+// checkVolumeTarget checks that the TV has a volume for target, such as "speaker" or "headphone"
+func (h *Handler) checkVolumeTarget(ctx context.Context, target string) error {
+	result, _, err := h.Client.Audio.GetVolumeInformation(ctx)
+	if err != nil {
+		return err
+	}
+	if result.Result == nil || len(*result.Result) == 0 {
+		return errors.New("invalid response from TV")
+	}
+
+	for _, info := range (*result.Result)[0] {
+		if info.Target == target {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w %q", errUnknownTarget, target)
+}
+
+// fade is a volume fade running in the background
+type fade struct {
+	cancel context.CancelFunc
+}
+
+// startFade fades the volume in the background, outliving the request that started it
+func (h *Handler) startFade(target string, to int, over time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	current := &fade{cancel: cancel}
+
+	h.mu.Lock()
+	if h.fade != nil {
+		h.fade.cancel()
+	}
+	h.fade = current
+	h.mu.Unlock()
+
+	go func() {
+		defer func() {
+			cancel()
+
+			h.mu.Lock()
+			if h.fade == current {
+				h.fade = nil
+			}
+			h.mu.Unlock()
+		}()
+
+		progress := FadeProgress{Type: "fade", Target: target, To: to, Volume: -1}
+		err := h.Client.Audio.RampVolume(ctx, target, to, over, api.WithRampProgress(func(volume int) {
+			progress.Volume = volume
+			h.events.publish(progress)
+		}))
+
+		progress.Done = true
+		if err != nil && !errors.Is(err, context.Canceled) {
+			progress.Error = err.Error()
+		}
+		h.events.publish(progress)
+	}()
+}
+
+// stopFade stops the running fade, reporting whether there was one
+func (h *Handler) stopFade() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.fade == nil {
+		return false
+	}
+	h.fade.cancel()
+	h.fade = nil
+	return true
+}
@@ -2,14 +2,27 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"sync"
 
 	"github.com/trugamr/bravia/api"
+	"github.com/trugamr/bravia/api/wol"
 )
 
 // Handler holds the Bravia API client for all handler functions
 type Handler struct {
 	Client *api.Client
+	// MACAddress is the MAC address of the TV, learnt from the TV when empty
+	MACAddress string
+	// WOLOptions configures the Wake-on-LAN packets sent when the TV doesn't answer
+	WOLOptions []wol.Option
+
+	mu       sync.Mutex
+	app      string            // URI of the last app opened from the remote
+	channels *api.ChannelIndex // Channels of the TV, read on first use
+	fade     *fade             // Running volume fade, if any
+	events   broadcaster       // Events pushed to SSE clients, such as fade progress
 }
 
 // NewHandler creates a new handler with the given Bravia API client
@@ -37,6 +50,40 @@ func respondWithError(w http.ResponseWriter, code int, message string) {
 	json.NewEncoder(w).Encode(ErrorResponse{Error: message})
 }
 
+// respondWithAPIError sends an error response with a status code matching the API error
+func respondWithAPIError(w http.ResponseWriter, err error) {
+	respondWithError(w, apiErrorStatus(err), err.Error())
+}
+
+// apiErrorStatus maps an error returned by the API client to an HTTP status code
+func apiErrorStatus(err error) int {
+	var irccErr *api.IRCCError
+	var modeErr *api.ModeError
+
+	switch {
+	case errors.Is(err, api.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, api.ErrForbidden):
+		return http.StatusForbidden
+	case api.IsUnsupported(err):
+		return http.StatusNotImplemented
+	case errors.Is(err, api.ErrDisplayOff):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, api.ErrIllegalState), errors.Is(err, api.ErrInputUnavailable):
+		return http.StatusConflict
+	case errors.Is(err, api.ErrChannelNotFound):
+		return http.StatusNotFound
+	case errors.As(err, &modeErr), errors.Is(err, api.ErrIllegalArgument):
+		// The mode or another argument isn't accepted by the API or the TV
+		return http.StatusBadRequest
+	case errors.As(err, &irccErr):
+		// The TV rejected the IRCC code that was sent
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // respondWithJSON sends a JSON response
 func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -17,9 +17,9 @@ func (h *Handler) InputsListHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, _, err := h.Client.AVContent.GetCurrentExternalInputsStatus()
+	result, _, err := h.Client.AVContent.GetCurrentExternalInputsStatus(r.Context())
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		respondWithAPIError(w, err)
 		return
 	}
 
@@ -51,9 +51,9 @@ func (h *Handler) InputsSelectHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, _, err := h.Client.AVContent.SetPlayContent(req.URI)
+	_, _, err := h.Client.AVContent.SetPlayContent(r.Context(), req.URI)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		respondWithAPIError(w, err)
 		return
 	}
 
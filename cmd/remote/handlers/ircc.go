@@ -2,12 +2,15 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+
+	"github.com/trugamr/bravia/api"
 )
 
 // IRCCSendRequest represents the request body for sending an IRCC command
 type IRCCSendRequest struct {
-	Command string `json:"command"`
+	Command string `json:"command"` // Command name, e.g. "VolumeUp", or raw IRCC code
 }
 
 // IRCCSendHandler sends an IRCC remote control command
@@ -28,11 +31,42 @@ func (h *Handler) IRCCSendHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err := h.Client.IRCC.SendIRCCCommand(req.Command)
+	command, err := h.Client.IRCC.Resolve(r.Context(), req.Command)
+	if errors.Is(err, api.ErrUnknownCommand) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithAPIError(w, err)
+		return
+	}
+
+	_, err = h.Client.IRCC.SendIRCCCommand(r.Context(), string(command))
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		respondWithAPIError(w, err)
 		return
 	}
 
 	respondWithSuccess(w, map[string]string{"command": req.Command})
 }
+
+// IRCCCodesHandler lists the IRCC commands supported by the TV
+func (h *Handler) IRCCCodesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	codes, err := h.Client.IRCC.Codes(r.Context())
+	if err != nil {
+		respondWithAPIError(w, err)
+		return
+	}
+
+	commands := make([]api.RemoteCommand, 0, len(codes))
+	for _, name := range codes.Names() {
+		commands = append(commands, api.RemoteCommand{Name: name, Value: string(codes[name])})
+	}
+
+	respondWithSuccess(w, commands)
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/trugamr/bravia/api"
+)
+
+// PictureSetRequest represents the request body for changing a picture quality setting
+type PictureSetRequest struct {
+	Target string `json:"target"`
+	Value  string `json:"value"`
+}
+
+// PictureSettingsHandler gets the picture quality settings and the values they accept
+func (h *Handler) PictureSettingsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	result, _, err := h.Client.Video.GetPictureQualitySettings(r.Context(), r.URL.Query().Get("target"))
+	if err != nil {
+		respondWithAPIError(w, err)
+		return
+	}
+
+	if result.Result == nil || len(*result.Result) == 0 {
+		respondWithError(w, http.StatusInternalServerError, "Invalid response from TV")
+		return
+	}
+
+	respondWithSuccess(w, (*result.Result)[0])
+}
+
+// PictureSetHandler changes a picture quality setting after checking the value against its candidates
+func (h *Handler) PictureSetHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req PictureSetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Target == "" || req.Value == "" {
+		respondWithError(w, http.StatusBadRequest, "target and value are required")
+		return
+	}
+
+	value, err := h.Client.Video.SetPictureQuality(r.Context(), req.Target, req.Value)
+	if err != nil {
+		respondWithAPIError(w, err)
+		return
+	}
+
+	respondWithSuccess(w, api.PictureQualityValue{Target: req.Target, Value: value})
+}
@@ -1,7 +1,10 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
+
+	"github.com/trugamr/bravia/api"
 )
 
 // PowerOnHandler turns the TV on
@@ -11,9 +14,18 @@ func (h *Handler) PowerOnHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, _, err := h.Client.System.SetPowerStatus(true)
+	woke, err := h.Client.System.PowerOn(r.Context(),
+		api.WithWakeOnLAN(h.macAddress(), h.WOLOptions...),
+		api.WithMACLearning(h.setMACAddress),
+	)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		respondWithAPIError(w, err)
+		return
+	}
+
+	// The TV's network stack was asleep, it was woken up with a magic packet instead
+	if woke {
+		respondWithSuccess(w, map[string]string{"status": "waking", "method": "wol"})
 		return
 	}
 
@@ -27,9 +39,12 @@ func (h *Handler) PowerOffHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, _, err := h.Client.System.SetPowerStatus(false)
+	// Learn the MAC address while the TV is still reachable, it's needed to turn it on again
+	h.learnMACAddress(r.Context())
+
+	_, _, err := h.Client.System.SetPowerStatus(r.Context(), false)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		respondWithAPIError(w, err)
 		return
 	}
 
@@ -43,9 +58,9 @@ func (h *Handler) PowerStatusHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, _, err := h.Client.System.GetPowerStatus()
+	result, _, err := h.Client.System.GetPowerStatus(r.Context())
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		respondWithAPIError(w, err)
 		return
 	}
 
@@ -58,3 +73,33 @@ func (h *Handler) PowerStatusHandler(w http.ResponseWriter, r *http.Request) {
 
 	respondWithSuccess(w, map[string]string{"status": status})
 }
+
+// macAddress returns the MAC address of the TV, if known
+func (h *Handler) macAddress() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.MACAddress
+}
+
+// learnMACAddress asks the TV for its MAC address if it isn't known yet, so the TV can be
+// woken up later. Failures are ignored, it's best effort.
+func (h *Handler) learnMACAddress(ctx context.Context) {
+	if h.macAddress() != "" {
+		return
+	}
+
+	mac, err := h.Client.System.MACAddress(ctx)
+	if err != nil {
+		return
+	}
+	h.setMACAddress(mac)
+}
+
+// setMACAddress remembers the MAC address of the TV
+func (h *Handler) setMACAddress(mac string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.MACAddress = mac
+}
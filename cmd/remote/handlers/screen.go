@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+)
+
+// ScreenOffHandler turns the picture off while the TV keeps playing
+func (h *Handler) ScreenOffHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	if err := h.Client.System.ScreenOff(r.Context()); err != nil {
+		respondWithAPIError(w, err)
+		return
+	}
+
+	respondWithSuccess(w, map[string]bool{"pictureOff": true})
+}
+
+// ScreenOnHandler turns the picture back on
+func (h *Handler) ScreenOnHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	if err := h.Client.System.ScreenOn(r.Context()); err != nil {
+		respondWithAPIError(w, err)
+		return
+	}
+
+	respondWithSuccess(w, map[string]bool{"pictureOff": false})
+}
+
+// ScreenStatusHandler gets whether the picture is off
+func (h *Handler) ScreenStatusHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	off, err := h.Client.System.IsScreenOff(r.Context())
+	if err != nil {
+		respondWithAPIError(w, err)
+		return
+	}
+
+	respondWithSuccess(w, map[string]bool{"pictureOff": off})
+}
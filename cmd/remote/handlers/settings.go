@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/trugamr/bravia/api"
+)
+
+// SettingModeRequest represents the request body for setting a power saving or LED indicator mode
+type SettingModeRequest struct {
+	Mode string `json:"mode"`
+}
+
+// WolModeRequest represents the request body for turning Wake-on-LAN on or off
+type WolModeRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// PowerSavingHandler gets the power saving mode on GET and sets it on POST
+func (h *Handler) PowerSavingHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		result, _, err := h.Client.System.GetPowerSavingMode(r.Context())
+		if err != nil {
+			respondWithAPIError(w, err)
+			return
+		}
+		if result.Result == nil || len(*result.Result) == 0 {
+			respondWithError(w, http.StatusInternalServerError, "Invalid response from TV")
+			return
+		}
+		respondWithSuccess(w, (*result.Result)[0])
+	case http.MethodPost:
+		var req SettingModeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		mode, err := h.Client.System.SetPowerSaving(r.Context(), req.Mode)
+		if err != nil {
+			respondWithAPIError(w, err)
+			return
+		}
+		respondWithSuccess(w, api.PowerSavingStatus{Mode: mode})
+	default:
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// LEDIndicatorHandler gets the LED indicator mode on GET and sets it on POST
+func (h *Handler) LEDIndicatorHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		result, _, err := h.Client.System.GetLEDIndicatorStatus(r.Context())
+		if err != nil {
+			respondWithAPIError(w, err)
+			return
+		}
+		if result.Result == nil || len(*result.Result) == 0 {
+			respondWithError(w, http.StatusInternalServerError, "Invalid response from TV")
+			return
+		}
+		respondWithSuccess(w, (*result.Result)[0])
+	case http.MethodPost:
+		var req SettingModeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+
+		mode, err := h.Client.System.SetLEDIndicator(r.Context(), req.Mode)
+		if err != nil {
+			respondWithAPIError(w, err)
+			return
+		}
+		respondWithSuccess(w, map[string]api.LEDIndicatorMode{"mode": mode})
+	default:
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// WolModeHandler gets whether Wake-on-LAN is on with GET and turns it on or off with POST
+func (h *Handler) WolModeHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		result, _, err := h.Client.System.GetWolMode(r.Context())
+		if err != nil {
+			respondWithAPIError(w, err)
+			return
+		}
+		if result.Result == nil || len(*result.Result) == 0 {
+			respondWithError(w, http.StatusInternalServerError, "Invalid response from TV")
+			return
+		}
+		respondWithSuccess(w, (*result.Result)[0])
+	case http.MethodPost:
+		var req WolModeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		if req.Enabled == nil {
+			respondWithError(w, http.StatusBadRequest, "enabled is required")
+			return
+		}
+
+		if _, _, err := h.Client.System.SetWolMode(r.Context(), *req.Enabled); err != nil {
+			respondWithAPIError(w, err)
+			return
+		}
+		respondWithSuccess(w, api.WolMode{Enabled: *req.Enabled})
+	default:
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/trugamr/bravia/api"
+)
+
+// SoundSetRequest represents the request body for changing a sound or speaker setting
+type SoundSetRequest struct {
+	Target string `json:"target"`
+	Value  string `json:"value"`
+}
+
+// SoundOutputRequest represents the request body for switching where the sound is played
+type SoundOutputRequest struct {
+	Output string `json:"output"`
+}
+
+// SoundSettingsHandler gets the sound settings and the values they accept
+func (h *Handler) SoundSettingsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	result, _, err := h.Client.Audio.GetSoundSettings(r.Context(), r.URL.Query().Get("target"))
+	if err != nil {
+		respondWithAPIError(w, err)
+		return
+	}
+
+	if result.Result == nil || len(*result.Result) == 0 {
+		respondWithError(w, http.StatusInternalServerError, "Invalid response from TV")
+		return
+	}
+
+	respondWithSuccess(w, (*result.Result)[0])
+}
+
+// SoundSetHandler changes a sound setting after checking the value against its candidates
+func (h *Handler) SoundSetHandler(w http.ResponseWriter, r *http.Request) {
+	setAudioSetting(w, r, h.Client.Audio.SetSound)
+}
+
+// SpeakerSettingsHandler gets the speaker settings and the values they accept
+func (h *Handler) SpeakerSettingsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	result, _, err := h.Client.Audio.GetSpeakerSettings(r.Context(), r.URL.Query().Get("target"))
+	if err != nil {
+		respondWithAPIError(w, err)
+		return
+	}
+
+	if result.Result == nil || len(*result.Result) == 0 {
+		respondWithError(w, http.StatusInternalServerError, "Invalid response from TV")
+		return
+	}
+
+	respondWithSuccess(w, (*result.Result)[0])
+}
+
+// SpeakerSetHandler changes a speaker setting after checking the value against its candidates
+func (h *Handler) SpeakerSetHandler(w http.ResponseWriter, r *http.Request) {
+	setAudioSetting(w, r, h.Client.Audio.SetSpeaker)
+}
+
+// SoundOutputHandler gets where the sound is played on GET and switches it on POST
+func (h *Handler) SoundOutputHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		result, _, err := h.Client.Audio.GetSoundSettings(r.Context(), api.SoundOutputTerminal)
+		if err != nil {
+			respondWithAPIError(w, err)
+			return
+		}
+		if result.Result == nil || len(*result.Result) == 0 {
+			respondWithError(w, http.StatusInternalServerError, "Invalid response from TV")
+			return
+		}
+		if len((*result.Result)[0]) == 0 {
+			respondWithError(w, http.StatusNotImplemented, "The TV does not report its sound output")
+			return
+		}
+		respondWithSuccess(w, map[string]string{"output": (*result.Result)[0][0].CurrentValue})
+	case http.MethodPost:
+		var req SoundOutputRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		if req.Output == "" {
+			respondWithError(w, http.StatusBadRequest, "output is required")
+			return
+		}
+
+		output, err := h.Client.Audio.SetSound(r.Context(), api.SoundOutputTerminal, req.Output)
+		if err != nil {
+			respondWithAPIError(w, err)
+			return
+		}
+		respondWithSuccess(w, map[string]string{"output": output})
+	default:
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// setAudioSetting decodes a SoundSetRequest and applies it with set
+func setAudioSetting(w http.ResponseWriter, r *http.Request, set func(ctx context.Context, target, value string) (string, error)) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req SoundSetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Target == "" || req.Value == "" {
+		respondWithError(w, http.StatusBadRequest, "target and value are required")
+		return
+	}
+
+	value, err := set(r.Context(), req.Target, req.Value)
+	if err != nil {
+		respondWithAPIError(w, err)
+		return
+	}
+
+	respondWithSuccess(w, api.AudioSettingValue{Target: req.Target, Value: value})
+}
@@ -1,17 +1,25 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 )
 
+// statePollTimeout bounds how long a single state poll may wait on the TV
+const statePollTimeout = 2 * time.Second
+
 // TVState represents the current state of the TV
 type TVState struct {
 	PowerStatus string `json:"powerStatus"`
 	Volume      int    `json:"volume"`
 	Muted       bool   `json:"muted"`
+	PictureOff  bool   `json:"pictureOff"`      // Whether the screen is blanked while the TV keeps playing
+	Input       string `json:"input,omitempty"` // URI of the input or channel being played
+	Title       string `json:"title,omitempty"` // Title of the input or channel being played
+	App         string `json:"app,omitempty"`   // URI of the app opened from the remote, while no input is played
 	Timestamp   string `json:"timestamp"`
 }
 
@@ -35,6 +43,10 @@ func (h *Handler) SSEHandler(w http.ResponseWriter, r *http.Request) {
 	ticker := time.NewTicker(3 * time.Second)
 	defer ticker.Stop()
 
+	// Receive events such as fade progress as they happen
+	events, unsubscribe := h.events.subscribe()
+	defer unsubscribe()
+
 	// Send initial connection message
 	fmt.Fprintf(w, "data: {\"type\":\"connected\"}\n\n")
 	flusher.Flush()
@@ -44,9 +56,15 @@ func (h *Handler) SSEHandler(w http.ResponseWriter, r *http.Request) {
 		case <-ctx.Done():
 			// Client disconnected
 			return
+		case event := <-events:
+			data, err := json.Marshal(event)
+			if err == nil {
+				fmt.Fprintf(w, "data: %s\n\n", data)
+				flusher.Flush()
+			}
 		case <-ticker.C:
 			// Poll TV state
-			state := h.getTVState()
+			state := h.getTVState(ctx)
 			if state != nil {
 				data, err := json.Marshal(state)
 				if err == nil {
@@ -59,7 +77,11 @@ func (h *Handler) SSEHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // getTVState polls the TV for current state
-func (h *Handler) getTVState() *TVState {
+func (h *Handler) getTVState(ctx context.Context) *TVState {
+	// Stop waiting on the TV once the poll interval is over or the client goes away
+	ctx, cancel := context.WithTimeout(ctx, statePollTimeout)
+	defer cancel()
+
 	state := &TVState{
 		PowerStatus: "unknown",
 		Volume:      0,
@@ -68,13 +90,34 @@ func (h *Handler) getTVState() *TVState {
 	}
 
 	// Get power status
-	powerResult, _, err := h.Client.System.GetPowerStatus()
+	powerResult, _, err := h.Client.System.GetPowerStatus(ctx)
 	if err == nil && powerResult.Result != nil && len(*powerResult.Result) > 0 {
 		state.PowerStatus = (*powerResult.Result)[0].Status
 	}
 
+	// Get whether the picture is off, which only matters while the TV is on
+	if state.PowerStatus == "active" {
+		savingResult, _, err := h.Client.System.GetPowerSavingMode(ctx)
+		if err == nil && savingResult.Result != nil && len(*savingResult.Result) > 0 {
+			state.PictureOff = (*savingResult.Result)[0].PictureOff()
+		}
+	}
+
+	// Get what is playing, the TV can't tell which app is in the foreground so use the last one opened
+	if state.PowerStatus == "active" {
+		info, err := h.Client.AVContent.NowPlaying(ctx)
+		if err == nil && info != nil {
+			state.Input = info.URI
+			state.Title = info.Title
+		} else if err == nil {
+			h.mu.Lock()
+			state.App = h.app
+			h.mu.Unlock()
+		}
+	}
+
 	// Get volume information
-	volumeResult, _, err := h.Client.Audio.GetVolumeInformation()
+	volumeResult, _, err := h.Client.Audio.GetVolumeInformation(ctx)
 	if err == nil && volumeResult.Result != nil && len(*volumeResult.Result) > 0 {
 		volumes := (*volumeResult.Result)[0]
 		for _, v := range volumes {
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/trugamr/bravia/api"
+)
+
+// SystemInfoResponse represents the information about the TV shown in the about panel
+type SystemInfoResponse struct {
+	System    *api.SystemInformation    `json:"system"`
+	Interface *api.InterfaceInformation `json:"interface,omitempty"`
+	Network   []api.NetworkSettings     `json:"network,omitempty"`
+	Functions []api.SupportedFunction   `json:"functions,omitempty"`
+}
+
+// SystemInfoHandler gets the model, network settings and supported functions of the TV
+func (h *Handler) SystemInfoHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var info SystemInfoResponse
+
+	system, _, err := h.Client.System.GetSystemInformation(r.Context())
+	if err != nil {
+		respondWithAPIError(w, err)
+		return
+	}
+	info.System = &(*system.Result)[0]
+
+	// The remaining calls aren't supported by every model, return what is available
+	iface, _, err := h.Client.System.GetInterfaceInformation(r.Context())
+	if err == nil {
+		info.Interface = &(*iface.Result)[0]
+	} else if !api.IsUnsupported(err) {
+		respondWithAPIError(w, err)
+		return
+	}
+
+	network, _, err := h.Client.System.GetNetworkSettings(r.Context(), "")
+	if err == nil {
+		info.Network = (*network.Result)[0]
+	} else if !api.IsUnsupported(err) {
+		respondWithAPIError(w, err)
+		return
+	}
+
+	functions, _, err := h.Client.System.GetSystemSupportedFunction(r.Context())
+	if err == nil {
+		info.Functions = (*functions.Result)[0]
+	} else if !api.IsUnsupported(err) {
+		respondWithAPIError(w, err)
+		return
+	}
+
+	respondWithSuccess(w, info)
+}
@@ -35,9 +35,9 @@ func (h *Handler) VolumeSetHandler(w http.ResponseWriter, r *http.Request) {
 		target = "speaker"
 	}
 
-	result, _, err := h.Client.Audio.SetAudioVolume(req.Volume, target)
+	result, _, err := h.Client.Audio.SetAudioVolume(r.Context(), req.Volume, target, nil)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		respondWithAPIError(w, err)
 		return
 	}
 
@@ -58,9 +58,9 @@ func (h *Handler) VolumeUpHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, _, err := h.Client.Audio.SetAudioVolume("+1", "speaker")
+	result, _, err := h.Client.Audio.SetAudioVolume(r.Context(), "+1", "speaker", nil)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		respondWithAPIError(w, err)
 		return
 	}
 
@@ -81,9 +81,9 @@ func (h *Handler) VolumeDownHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, _, err := h.Client.Audio.SetAudioVolume("-1", "speaker")
+	result, _, err := h.Client.Audio.SetAudioVolume(r.Context(), "-1", "speaker", nil)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		respondWithAPIError(w, err)
 		return
 	}
 
@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"io/fs"
 	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
+	"time"
 
 	"github.com/trugamr/bravia/api"
+	"github.com/trugamr/bravia/api/replay"
+	"github.com/trugamr/bravia/api/wol"
 	"github.com/trugamr/bravia/cmd/remote/config"
 	"github.com/trugamr/bravia/cmd/remote/handlers"
 )
@@ -32,10 +36,27 @@ func main() {
 		os.Exit(1)
 	}
 
-	client := api.NewClient(baseURL).WithAuthPSK(cfg.PSK)
+	opts, err := clientOptions(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating API client: %s\n", err)
+		os.Exit(1)
+	}
+	client := api.NewClient(baseURL, opts...)
+
+	// Use the credential from `bravia pair`, registering again when it expires
+	if cfg.AuthCookie != "" {
+		registration := api.Registration{ClientID: cfg.ClientID, Nickname: "Bravia Remote"}
+		client = client.WithPairing(registration, cfg.AuthCookie, func(cookie string) {
+			if err := cfg.Write(map[string]interface{}{"auth_cookie": cookie}); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to save renewed auth cookie: %s\n", err)
+			}
+		})
+	}
 
 	// Create handler with client
 	h := handlers.NewHandler(client)
+	h.MACAddress = cfg.MACAddress
+	h.WOLOptions = wol.Settings{Broadcast: cfg.WOLBroadcast, Port: cfg.WOLPort, Repeat: cfg.WOLRepeat}.Options()
 
 	// Set up HTTP routes
 	mux := http.NewServeMux()
@@ -45,9 +66,29 @@ func main() {
 	mux.HandleFunc("/api/power/off", h.PowerOffHandler)
 	mux.HandleFunc("/api/power/status", h.PowerStatusHandler)
 
+	mux.HandleFunc("/api/screen/off", h.ScreenOffHandler)
+	mux.HandleFunc("/api/screen/on", h.ScreenOnHandler)
+	mux.HandleFunc("/api/screen/status", h.ScreenStatusHandler)
+
+	mux.HandleFunc("/api/system/info", h.SystemInfoHandler)
+
+	mux.HandleFunc("/api/settings/power-saving", h.PowerSavingHandler)
+	mux.HandleFunc("/api/settings/led", h.LEDIndicatorHandler)
+	mux.HandleFunc("/api/settings/wol", h.WolModeHandler)
+
 	mux.HandleFunc("/api/volume/set", h.VolumeSetHandler)
 	mux.HandleFunc("/api/volume/up", h.VolumeUpHandler)
 	mux.HandleFunc("/api/volume/down", h.VolumeDownHandler)
+	mux.HandleFunc("/api/volume/fade", h.VolumeFadeHandler)
+
+	mux.HandleFunc("/api/sound/settings", h.SoundSettingsHandler)
+	mux.HandleFunc("/api/sound/set", h.SoundSetHandler)
+	mux.HandleFunc("/api/sound/output", h.SoundOutputHandler)
+	mux.HandleFunc("/api/sound/speaker", h.SpeakerSettingsHandler)
+	mux.HandleFunc("/api/sound/speaker/set", h.SpeakerSetHandler)
+
+	mux.HandleFunc("/api/picture", h.PictureSettingsHandler)
+	mux.HandleFunc("/api/picture/set", h.PictureSetHandler)
 
 	mux.HandleFunc("/api/apps", h.AppsListHandler)
 	mux.HandleFunc("/api/apps/open", h.AppsOpenHandler)
@@ -55,7 +96,11 @@ func main() {
 	mux.HandleFunc("/api/inputs", h.InputsListHandler)
 	mux.HandleFunc("/api/inputs/select", h.InputsSelectHandler)
 
+	mux.HandleFunc("/api/channels", h.ChannelsListHandler)
+	mux.HandleFunc("/api/channels/tune", h.ChannelsTuneHandler)
+
 	mux.HandleFunc("/api/ircc/send", h.IRCCSendHandler)
+	mux.HandleFunc("/api/ircc/codes", h.IRCCCodesHandler)
 
 	mux.HandleFunc("/api/sse", h.SSEHandler)
 
@@ -94,3 +139,53 @@ func corsMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// clientOptions returns the API client options from the config
+func clientOptions(cfg *config.Config) ([]api.Option, error) {
+	opts := []api.Option{
+		api.WithAuthPSK(cfg.PSK),
+		api.WithUserAgent("bravia-remote"),
+		api.WithLogger(newLogger(cfg)),
+	}
+	if cfg.Retries > 0 {
+		opts = append(opts, api.WithRetry(api.RetryPolicy{
+			Attempts:   cfg.Retries + 1,
+			MinBackoff: cfg.RetryBackoff,
+			MaxBackoff: 5 * time.Second,
+		}))
+	}
+	switch {
+	case cfg.Record != "":
+		recorder, err := replay.NewRecorder(cfg.Record, http.DefaultTransport)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, api.WithHTTPClient(&http.Client{Transport: recorder}))
+	case cfg.Replay != "":
+		player, err := replay.Load(cfg.Replay)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, api.WithHTTPClient(&http.Client{Transport: player}))
+	}
+	if cfg.MaxConcurrent > 0 {
+		opts = append(opts, api.WithConcurrencyLimit(cfg.MaxConcurrent))
+	}
+	if cfg.BreakerThreshold > 0 {
+		opts = append(opts, api.WithCircuitBreaker(api.CircuitBreaker{
+			Threshold: cfg.BreakerThreshold,
+			Cooldown:  cfg.BreakerCooldown,
+		}))
+	}
+	return opts, nil
+}
+
+// newLogger returns the logger for calls to the TV, at the level from the config
+func newLogger(cfg *config.Config) *slog.Logger {
+	level, _ := cfg.Level()
+
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: api.ReplaceLevelNames,
+	}))
+}
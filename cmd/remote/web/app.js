@@ -72,6 +72,28 @@ document.querySelectorAll('[data-ircc]').forEach(button => {
     });
 });
 
+// Hide buttons for IRCC codes the TV doesn't support
+async function loadIRCCCodes() {
+    try {
+        const response = await fetch('/api/ircc/codes');
+        if (!response.ok) {
+            return;
+        }
+
+        const result = await response.json();
+        const supported = new Set(result.data.map(command => command.value));
+
+        document.querySelectorAll('[data-ircc]').forEach(button => {
+            button.style.display = supported.has(button.getAttribute('data-ircc')) ? '' : 'none';
+        });
+    } catch (error) {
+        // Keep all buttons visible when the TV can't be reached
+        console.error('Failed to load IRCC codes:', error);
+    }
+}
+
+loadIRCCCodes();
+
 // Fallback icon for apps without proper icon URL
 function getFallbackIcon() {
     return '<svg class="w-8 h-8 text-slate-400" viewBox="0 0 24 24" fill="currentColor"><path d="M4 8h4V4H4v4zm6 12h4v-4h-4v4zm-6 0h4v-4H4v4zm0-6h4v-4H4v4zm6 0h4v-4h-4v4zm6-10v4h4V4h-4zm-6 4h4V4h-4v4zm6 6h4v-4h-4v4zm0 6h4v-4h-4v4z"></path></svg>';
@@ -213,6 +235,9 @@ document.getElementById('load-inputs').addEventListener('click', async () => {
                 <span class="text-sm font-medium">${displayText}</span>
             `;
 
+            inputEl.dataset.uri = input.uri;
+            inputEl.classList.toggle('ring-2', input.uri === activeInput);
+            inputEl.classList.toggle('ring-purple-500', input.uri === activeInput);
             inputEl.addEventListener('click', async function() {
                 try {
                     this.classList.add('btn-loading');
@@ -238,6 +263,62 @@ document.getElementById('load-inputs').addEventListener('click', async () => {
     }
 });
 
+// Channels, kept by the server once read from the TV unless a rescan is asked for
+async function loadChannels(refresh) {
+    const channelsGrid = document.getElementById('channels-grid');
+    channelsGrid.innerHTML = '<div class="col-span-2 text-center py-4 text-slate-500 text-sm">Loading channels...</div>';
+
+    try {
+        const result = await apiCall(refresh ? '/api/channels?refresh=true' : '/api/channels');
+        const channels = result.data;
+
+        channelsGrid.innerHTML = '';
+
+        if (channels.length === 0) {
+            channelsGrid.innerHTML = '<div class="col-span-2 text-center py-4 text-slate-500 text-sm">No channels found</div>';
+            return;
+        }
+
+        channels.forEach(channel => {
+            const channelEl = document.createElement('button');
+            channelEl.className = 'btn bg-slate-50 hover:bg-slate-100 active:bg-slate-200 text-slate-700 py-2 px-3 justify-start border border-slate-200';
+            channelEl.innerHTML = `
+                <span class="text-xs font-mono text-slate-400">${escapeHTML(channel.dispNum)}</span>
+                <span class="text-sm font-medium truncate">${escapeHTML(channel.name)}</span>
+            `;
+            channelEl.dataset.uri = channel.uri;
+            channelEl.classList.toggle('ring-2', channel.uri === activeInput);
+            channelEl.classList.toggle('ring-purple-500', channel.uri === activeInput);
+
+            channelEl.addEventListener('click', async function() {
+                try {
+                    this.classList.add('btn-loading');
+                    await apiCall('/api/channels/tune', {
+                        method: 'POST',
+                        body: JSON.stringify({ uri: channel.uri })
+                    });
+                    updateActiveInput(channel.uri);
+                    showToast(`Tuned to ${channel.name}`, 'success');
+                    setTimeout(() => this.classList.remove('btn-loading'), 300);
+                } catch (error) {
+                    console.error('Failed to tune channel:', error);
+                    this.classList.remove('btn-loading');
+                }
+            });
+
+            channelsGrid.appendChild(channelEl);
+        });
+
+        showToast(`Loaded ${channels.length} channels`, 'success');
+    } catch (error) {
+        channelsGrid.innerHTML = '<div class="col-span-2 text-center py-4 text-red-500 text-sm">Failed to load channels</div>';
+        console.error('Failed to load channels:', error);
+    }
+}
+
+document.getElementById('load-channels').addEventListener('click', () => loadChannels(false));
+document.getElementById('refresh-channels').addEventListener('click', () => loadChannels(true));
+
 // Keyboard shortcuts
 document.addEventListener('keydown', (e) => {
     // Prevent shortcuts when typing in input fields
@@ -258,6 +339,54 @@ document.addEventListener('keydown', (e) => {
     }
 });
 
+// URI of the input or channel being played, reported by the state updates
+let activeInput = '';
+
+// Highlight the tile of the input or channel being played
+function updateActiveInput(uri) {
+    activeInput = uri || '';
+    document.querySelectorAll('#inputs-grid [data-uri], #channels-grid [data-uri]').forEach(el => {
+        const active = el.dataset.uri === activeInput;
+        el.classList.toggle('ring-2', active);
+        el.classList.toggle('ring-purple-500', active);
+    });
+}
+
+// Screen (picture off while the sound keeps playing)
+let pictureOff = false;
+
+function updateScreenToggle(off) {
+    pictureOff = off;
+    document.getElementById('screen-label').textContent = off ? 'Screen On' : 'Screen Off';
+}
+
+document.getElementById('screen-toggle').addEventListener('click', async function() {
+    this.classList.add('btn-loading');
+    try {
+        const result = await apiCall(pictureOff ? '/api/screen/on' : '/api/screen/off', { method: 'POST' });
+        updateScreenToggle(result.data.pictureOff);
+        showToast(result.data.pictureOff ? 'Picture off' : 'Picture on');
+    } catch (error) {
+        console.error('Failed to toggle the screen:', error);
+    } finally {
+        this.classList.remove('btn-loading');
+    }
+});
+
+// Volume fade progress, started with POST /api/volume/fade
+function updateFade(fade) {
+    const volumeEl = document.getElementById('status-volume');
+    if (volumeEl && fade.volume >= 0) {
+        volumeEl.textContent = fade.volume;
+    }
+
+    if (fade.done && fade.error) {
+        showToast(`Fade failed: ${fade.error}`, 'error');
+    } else if (fade.done && fade.volume >= 0) {
+        showToast(`Volume faded to ${fade.volume}`);
+    }
+}
+
 // SSE Connection for real-time TV state updates
 function connectSSE() {
     const eventSource = new EventSource('/api/sse');
@@ -273,6 +402,12 @@ function connectSSE() {
             // Skip connection messages
             if (data.type === 'connected') return;
 
+            // Volume fades report their progress between state updates
+            if (data.type === 'fade') {
+                updateFade(data);
+                return;
+            }
+
             // Update header status bar
             const statusEl = document.getElementById('status');
             const isActive = data.powerStatus === 'active';
@@ -285,7 +420,7 @@ function connectSSE() {
                         <svg class="w-3.5 h-3.5" viewBox="0 0 24 24" fill="currentColor">
                             <circle cx="12" cy="12" r="10"></circle>
                         </svg>
-                        <span class="text-xs font-semibold">${isActive ? 'ON' : 'OFF'}</span>
+                        <span class="text-xs font-semibold">${isActive ? (data.pictureOff ? 'PICTURE OFF' : 'ON') : 'OFF'}</span>
                     </div>
                     <div class="flex items-center gap-1.5 ${volumeColor}">
                         <svg class="w-4 h-4" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-width="2">
@@ -294,11 +429,14 @@ function connectSSE() {
                                 : '<polygon points="11 5 6 9 2 9 2 15 6 15 11 19 11 5"></polygon><path d="M15.54 8.46a5 5 0 0 1 0 7.07"></path>'
                             }
                         </svg>
-                        <span class="text-xs font-semibold">${data.volume}</span>
+                        <span id="status-volume" class="text-xs font-semibold">${data.volume}</span>
                     </div>
+                    ${isActive && data.title ? `<span class="text-xs font-semibold text-slate-500 truncate max-w-[10rem]">${escapeHTML(data.title)}</span>` : ''}
                 </div>
             `;
 
+            updateScreenToggle(isActive && data.pictureOff);
+            updateActiveInput(isActive ? data.input : '');
         } catch (error) {
             console.error('Error parsing SSE data:', error);
         }
@@ -319,3 +457,117 @@ function connectSSE() {
 
 // Start SSE connection
 connectSSE();
+
+// About
+function escapeHTML(value) {
+    const div = document.createElement('div');
+    div.textContent = value ?? '';
+    return div.innerHTML;
+}
+
+document.getElementById('load-about').addEventListener('click', async () => {
+    const aboutInfo = document.getElementById('about-info');
+    aboutInfo.innerHTML = '<div class="text-center py-4 text-slate-500 text-sm">Loading information...</div>';
+
+    try {
+        const result = await apiCall('/api/system/info');
+        const info = result.data;
+        const network = (info.network || []).find(netif => netif.ipAddrV4) || {};
+
+        const rows = [
+            ['Model', info.system.model],
+            ['Name', info.system.name],
+            ['Serial', info.system.serial],
+            ['Generation', info.system.generation],
+            ['Area', info.system.area],
+            ['Language', info.system.language],
+            ['API version', info.interface?.interfaceVersion],
+            ['IP address', network.ipAddrV4],
+            ['MAC address', info.system.macAddr || network.hwAddr],
+            ['Gateway', network.gateway],
+        ].filter(([, value]) => value);
+
+        aboutInfo.innerHTML = `
+            <dl class="grid grid-cols-2 gap-x-4 gap-y-1">
+                ${rows.map(([label, value]) => `
+                    <dt class="text-slate-500">${label}</dt>
+                    <dd class="text-slate-800 font-medium break-all">${escapeHTML(value)}</dd>
+                `).join('')}
+            </dl>
+        `;
+    } catch (error) {
+        aboutInfo.innerHTML = '<div class="text-center py-4 text-red-500 text-sm">Failed to load information</div>';
+        console.error('Failed to load TV information:', error);
+    }
+});
+
+// Picture
+async function setPicture(target, value) {
+    try {
+        const result = await apiCall('/api/picture/set', {
+            method: 'POST',
+            body: JSON.stringify({ target, value })
+        });
+        showToast(`${target} set to ${result.data.value}`);
+    } catch (error) {
+        console.error('Failed to change picture setting:', error);
+        loadPicture();
+    }
+}
+
+function pictureControl(setting) {
+    const disabled = setting.isAvailable ? '' : 'disabled';
+    const candidates = setting.candidate || [];
+    const range = candidates.find(candidate => !candidate.value);
+
+    if (range) {
+        return `
+            <input type="range" data-target="${escapeHTML(setting.target)}" ${disabled}
+                min="${range.min || 0}" max="${range.max || 0}" step="${range.step || 1}"
+                value="${escapeHTML(setting.currentValue)}" class="w-full accent-blue-600">
+        `;
+    }
+
+    const options = candidates.map(candidate => `
+        <option value="${escapeHTML(candidate.value)}" ${candidate.value === setting.currentValue ? 'selected' : ''}>
+            ${escapeHTML(candidate.value)}
+        </option>
+    `).join('');
+    return `
+        <select data-target="${escapeHTML(setting.target)}" ${disabled}
+            class="w-full rounded border border-slate-200 bg-slate-50 px-2 py-1 text-sm">
+            ${options}
+        </select>
+    `;
+}
+
+async function loadPicture() {
+    const pictureSettings = document.getElementById('picture-settings');
+    pictureSettings.innerHTML = '<div class="text-center py-4 text-slate-500 text-sm">Loading settings...</div>';
+
+    try {
+        const result = await apiCall('/api/picture');
+        const settings = result.data || [];
+
+        if (settings.length === 0) {
+            pictureSettings.innerHTML = '<div class="text-center py-4 text-slate-400 text-xs">No picture settings available</div>';
+            return;
+        }
+
+        pictureSettings.innerHTML = settings.map(setting => `
+            <label class="grid grid-cols-2 items-center gap-2">
+                <span class="text-slate-500">${escapeHTML(setting.target)}</span>
+                ${pictureControl(setting)}
+            </label>
+        `).join('');
+
+        pictureSettings.querySelectorAll('[data-target]').forEach(control => {
+            control.addEventListener('change', () => setPicture(control.dataset.target, control.value));
+        });
+    } catch (error) {
+        pictureSettings.innerHTML = '<div class="text-center py-4 text-red-500 text-sm">Failed to load picture settings</div>';
+        console.error('Failed to load picture settings:', error);
+    }
+}
+
+document.getElementById('load-picture').addEventListener('click', loadPicture);
@@ -1,6 +1,6 @@
 module github.com/trugamr/bravia
 
-go 1.22.3
+go 1.23.0
 
 require (
 	github.com/lithammer/fuzzysearch v1.1.8